@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 
-	"github.com/Pradyothsp/pyinit/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -16,15 +15,19 @@ func (c *Commands) createBannerCommands() *cobra.Command {
 	}
 
 	bannerEnableCmd := &cobra.Command{
-		Use:   "enable",
-		Short: "Enable ASCII banner",
-		Run:   c.enableBanner,
+		Use:           "enable",
+		Short:         "Enable ASCII banner",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.enableBanner,
 	}
 
 	bannerDisableCmd := &cobra.Command{
-		Use:   "disable",
-		Short: "Disable ASCII banner",
-		Run:   c.disableBanner,
+		Use:           "disable",
+		Short:         "Disable ASCII banner",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.disableBanner,
 	}
 
 	bannerCmd.AddCommand(bannerEnableCmd, bannerDisableCmd)
@@ -32,33 +35,33 @@ func (c *Commands) createBannerCommands() *cobra.Command {
 }
 
 // enableBanner enables the banner display
-func (c *Commands) enableBanner(cmd *cobra.Command, args []string) {
-	banner, err := ui.NewBanner()
-	if err != nil {
-		fmt.Printf("Error: Failed to initialize banner system: %v\n", err)
-		return
-	}
-
-	if err := banner.Enable(); err != nil {
-		fmt.Printf("Error: Failed to enable banner: %v\n", err)
-		return
+func (c *Commands) enableBanner(cmd *cobra.Command, args []string) error {
+	if err := setBanner(true); err != nil {
+		return fmt.Errorf("failed to enable banner: %w", err)
 	}
 
-	fmt.Println("✅ Banner enabled")
+	fmt.Fprintln(cmd.OutOrStdout(), "✅ Banner enabled")
+	return nil
 }
 
 // disableBanner disables the banner display
-func (c *Commands) disableBanner(cmd *cobra.Command, args []string) {
-	banner, err := ui.NewBanner()
-	if err != nil {
-		fmt.Printf("Error: Failed to initialize banner system: %v\n", err)
-		return
+func (c *Commands) disableBanner(cmd *cobra.Command, args []string) error {
+	if err := setBanner(false); err != nil {
+		return fmt.Errorf("failed to disable banner: %w", err)
 	}
 
-	if err := banner.Disable(); err != nil {
-		fmt.Printf("Error: Failed to disable banner: %v\n", err)
-		return
+	fmt.Fprintln(cmd.OutOrStdout(), "✅ Banner disabled")
+	return nil
+}
+
+// setBanner stores whether the banner is shown. A config file that does not load is
+// reported rather than replaced, so its other settings are kept.
+func setBanner(enabled bool) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
 	}
 
-	fmt.Println("✅ Banner disabled")
+	settings.UI.ShowBanner = enabled
+	return settings.Save()
 }
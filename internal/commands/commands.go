@@ -14,6 +14,9 @@ func NewCommands() *Commands {
 	cmd := &Commands{}
 	cmd.setupRootCommand()
 	cmd.setupConfigCommands()
+	cmd.setupNewCommand()
+	cmd.setupTemplateCommands()
+	cmd.setupRenderCommand()
 	return cmd
 }
 
@@ -33,6 +36,31 @@ func (c *Commands) setupRootCommand() {
 	
 	// Add version flag
 	c.rootCmd.Flags().BoolP("version", "v", false, "Show version information")
+
+	// Add answers file flag
+	c.rootCmd.Flags().String("answers", "", "Answers file (YAML, JSON or TOML) to replay; only missing answers are prompted")
+
+	// Add dry-run flags
+	addDryRunFlags(c.rootCmd)
+
+	// Add template override flag
+	addTemplateDirFlag(c.rootCmd)
+}
+
+// addTemplateDirFlag adds the flag that layers a template directory over the embedded templates
+func addTemplateDirFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template-dir", "", "Directory of templates that override the built-in ones (default: templates.dir in the config file)")
+}
+
+// addDryRunFlags adds the flags that preview generation instead of writing files
+func addDryRunFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "Print the files that would be created without writing anything")
+	cmd.Flags().Bool("show-content", false, "With --dry-run, also print the rendered content of every file")
+}
+
+// setupNewCommand adds the non-interactive project creation command
+func (c *Commands) setupNewCommand() {
+	c.rootCmd.AddCommand(c.createNewCommand())
 }
 
 // setupConfigCommands adds all config-related commands
@@ -40,13 +68,14 @@ func (c *Commands) setupConfigCommands() {
 	configCmd := &cobra.Command{
 		Use:   "config",
 		Short: "Manage pyinit configuration",
-		Long:  "Configure pyinit behavior via ~/.pyinitrc",
+		Long:  "Configure pyinit behavior via $XDG_CONFIG_HOME/pyinit/config.toml (~/.config/pyinit/config.toml by default)",
 	}
 
 	// Add config subcommands
 	configCmd.AddCommand(c.createConfigShowCommand())
 	configCmd.AddCommand(c.createConfigResetCommand())
 	configCmd.AddCommand(c.createBannerCommands())
+	configCmd.AddCommand(c.createConfigSettingCommands()...)
 
 	c.rootCmd.AddCommand(configCmd)
 }
@@ -2,10 +2,14 @@ package commands
 
 import (
 	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/Pradyothsp/pyinit/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -109,6 +113,93 @@ func TestConfigCommandStructure(t *testing.T) {
 	}
 }
 
+func TestConfigSettingCommands(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	run := func(args ...string) (string, error) {
+		rootCmd := NewCommands().rootCmd
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetArgs(append([]string{"config"}, args...))
+		err := rootCmd.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("set", "defaults.author", "Jane Doe"); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	// The names ~/.pyinitrc used still work
+	if _, err := run("set", "remember_last", "true"); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if output, err := run("get", "defaults.author"); err != nil || output != "Jane Doe\n" {
+		t.Errorf("config get defaults.author = %q, %v, want %q", output, err, "Jane Doe\n")
+	}
+
+	content, err := os.ReadFile(filepath.Join(configHome, "pyinit", "config.toml"))
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	for _, want := range []string{"[defaults]\n", "author = \"Jane Doe\"\n", "remember_last = true\n", "# email ="} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Config file is missing %q:\n%s", want, content)
+		}
+	}
+
+	if _, err := run("unset", "defaults.author"); err != nil {
+		t.Fatalf("config unset failed: %v", err)
+	}
+	if output, _ := run("get", "defaults.author"); output != "\n" {
+		t.Errorf("config get after unset = %q, want an empty value", output)
+	}
+	if output, _ := run("get", "defaults.remember_last"); output != "true\n" {
+		t.Errorf("config get remember_last = %q, want it kept", output)
+	}
+
+	// Values are checked the way the prompts they pre-fill check answers
+	for _, args := range [][]string{
+		{"set", "defaults.email", "not-an-email"},
+		{"set", "defaults.python_version", "3.8"},
+		{"set", "defaults.project_type", "rocket"},
+		{"set", "defaults.remember_last", "maybe"},
+		{"set", "setup.environment", "sometimes"},
+		{"set", "favourite_colour", "blue"},
+		{"get", "favourite_colour"},
+	} {
+		if _, err := run(args...); err == nil {
+			t.Errorf("config %v succeeded, want an error", args)
+		}
+	}
+}
+
+func TestBannerCommandKeepsInvalidConfig(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	path := filepath.Join(configHome, "pyinit", "config.toml")
+	original := "[defaults]\nauthor = \"Jane Doe\"\nemail = \"jane@example.com\"\ncolour = \"blue\"\n"
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	rootCmd := NewCommands().rootCmd
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"config", "banner", "disable"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Errorf("config banner disable error = %v, want the schema error", err)
+	}
+
+	if content, _ := os.ReadFile(path); string(content) != original {
+		t.Errorf("Config file was rewritten:\n%s", content)
+	}
+}
+
 func TestConfigShowCommand(t *testing.T) {
 	commands := NewCommands()
 	
@@ -328,7 +419,7 @@ func TestCommandCompleteness(t *testing.T) {
 	addSubcommands(rootCmd)
 
 	// Verify expected commands exist
-	expectedCommands := []string{"pyinit", "config", "show", "reset", "banner"}
+	expectedCommands := []string{"pyinit", "config", "show", "reset", "banner", "template", "list", "lint [dir]", "render <template>", "set <key> <value>", "get <key>", "unset <key>"}
 	for _, expected := range expectedCommands {
 		if _, exists := allCommands[expected]; !exists {
 			t.Errorf("Expected command %q not found in command tree", expected)
@@ -382,4 +473,516 @@ func BenchmarkCommandExecution(b *testing.B) {
 		buf.Reset()
 		rootCmd.Execute()
 	}
-}
\ No newline at end of file
+}
+
+func TestNewCommandFlags(t *testing.T) {
+	commands := NewCommands()
+	newCmd := commands.createNewCommand()
+
+	if newCmd.Use != "new" {
+		t.Errorf("New command Use = %q, want %q", newCmd.Use, "new")
+	}
+
+	if newCmd.RunE == nil {
+		t.Error("New command RunE function is nil")
+	}
+
+	expectedFlags := []string{"name", "type", "framework", "main-dir", "python", "author", "email", "description", "path"}
+	for _, name := range expectedFlags {
+		if newCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected new command flag --%s not found", name)
+		}
+	}
+}
+
+func TestNewCommandMissingRequiredFlag(t *testing.T) {
+	commands := NewCommands()
+	rootCmd := commands.rootCmd
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"new", "--name", "demo", "--email", "demo@example.com", "--main-dir", "demo"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error when --author is missing, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "--author") {
+		t.Errorf("Expected error to mention --author, got: %v", err)
+	}
+}
+
+func TestNewCommandInvalidType(t *testing.T) {
+	commands := NewCommands()
+	rootCmd := commands.rootCmd
+
+	rootCmd.SetArgs([]string{
+		"new", "--name", "demo", "--author", "Demo", "--email", "demo@example.com",
+		"--main-dir", "demo", "--type", "spaceship",
+	})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for unknown project type, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "--type") {
+		t.Errorf("Expected error to mention --type, got: %v", err)
+	}
+}
+
+func TestNewCommandDerivesMainDir(t *testing.T) {
+	tempDir := t.TempDir()
+
+	commands := NewCommands()
+	rootCmd := commands.rootCmd
+	rootCmd.SetArgs([]string{
+		"new", "--name", "Ünïcode App", "--author", "Demo", "--email", "demo@example.com",
+		"--path", tempDir,
+	})
+
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--name") {
+		t.Fatalf("Expected the unicode name to be rejected, got: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{
+		"new", "--name", "2048 Game", "--author", "Demo", "--email", "demo@example.com",
+		"--path", tempDir,
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("new command failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "2048-game", "_2048_game", "main.py")); err != nil {
+		t.Errorf("Expected the package directory derived from the project name: %v", err)
+	}
+}
+
+func TestNewCommandUsesConfigTemplateDir(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	templateDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(templateDir, "basic"), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "basic", "main.py.j2"), []byte("print(\"from config\")\n"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	configPath := filepath.Join(configHome, "pyinit", "config.toml")
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+			t.Fatalf("Failed to create config dir: %v", err)
+		}
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+	runNew := func(parent string) error {
+		rootCmd := NewCommands().rootCmd
+		rootCmd.SetArgs([]string{"new", "--name", "demo", "--author", "Demo", "--email", "demo@example.com", "--path", parent})
+		return rootCmd.Execute()
+	}
+
+	writeConfig(fmt.Sprintf("[templates]\ndir = %q\n", templateDir))
+	parent := t.TempDir()
+	if err := runNew(parent); err != nil {
+		t.Fatalf("new command failed: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(parent, "demo", "demo", "main.py")); string(content) != "print(\"from config\")\n" {
+		t.Errorf("main.py = %q, want the template from templates.dir", content)
+	}
+
+	// A config file that does not load is reported instead of dropping templates.dir
+	writeConfig(fmt.Sprintf("[templates]\ndir = %q\ncolour = \"blue\"\n", templateDir))
+	if err := runNew(t.TempDir()); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Errorf("new command error = %v, want the config file error", err)
+	}
+}
+
+func TestNewCommandSuggestsCorrections(t *testing.T) {
+	tests := []struct {
+		flag, value, want string
+	}{
+		{flag: "--main-dir", value: "my-pkg", want: `invalid value for --main-dir: "my-pkg" is not importable`},
+		{flag: "--python", value: "3.12.1", want: `(try "3.12")`},
+		{flag: "--name", value: "Ünïcode App", want: `(try "unicode-app")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			commands := NewCommands()
+			rootCmd := commands.rootCmd
+
+			args := map[string]string{
+				"--name": "demo", "--author": "Demo", "--email": "demo@example.com",
+				"--main-dir": "demo", "--python": "3.13",
+			}
+			args[tt.flag] = tt.value
+
+			cmdArgs := []string{"new", "--dry-run"}
+			for flag, value := range args {
+				cmdArgs = append(cmdArgs, flag, value)
+			}
+			rootCmd.SetArgs(cmdArgs)
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Execute() error = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCommandGeneratesProject(t *testing.T) {
+	tempDir := t.TempDir()
+
+	commands := NewCommands()
+	rootCmd := commands.rootCmd
+	rootCmd.SetArgs([]string{
+		"new", "--name", "Demo App", "--author", "Demo", "--email", "demo@example.com",
+		"--main-dir", "demo_app", "--path", tempDir,
+	})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("new command failed: %v", err)
+	}
+
+	for _, file := range []string{"pyproject.toml", "README.md", "demo_app/main.py"} {
+		if _, err := os.Stat(filepath.Join(tempDir, "demo-app", file)); err != nil {
+			t.Errorf("Expected generated file %s: %v", file, err)
+		}
+	}
+
+	// A second run must refuse to touch the existing directory without --force
+	rootCmd.SetArgs([]string{
+		"new", "--name", "Demo App", "--author", "Demo", "--email", "demo@example.com",
+		"--main-dir", "demo_app", "--path", tempDir,
+	})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("Expected error when project directory already exists, got nil")
+	}
+}
+
+func TestNewCommandRejectsUnsupportedExtras(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		flag string
+	}{
+		{name: "deps on basic project", args: []string{"--deps", "requests"}, flag: "--deps"},
+		{name: "kernel on basic project", args: []string{"--register-kernel"}, flag: "--register-kernel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := NewCommands()
+			rootCmd := commands.rootCmd
+			rootCmd.SetArgs(append([]string{
+				"new", "--name", "demo", "--author", "Demo", "--email", "demo@example.com",
+				"--main-dir", "demo", "--path", t.TempDir(),
+			}, tt.args...))
+
+			err := rootCmd.Execute()
+			if err == nil {
+				t.Fatalf("Expected error for %s on a basic project, got nil", tt.flag)
+			}
+			if !strings.Contains(err.Error(), tt.flag) {
+				t.Errorf("Expected error to mention %s, got: %v", tt.flag, err)
+			}
+		})
+	}
+}
+
+func TestNewCommandTemplateDir(t *testing.T) {
+	tempDir := t.TempDir()
+
+	templateDir := filepath.Join(tempDir, "templates")
+	if err := os.MkdirAll(filepath.Join(templateDir, "basic"), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "basic", "README.md.j2"), []byte("# {{ project_name }} (team template)\n"), 0644); err != nil {
+		t.Fatalf("Failed to write override template: %v", err)
+	}
+
+	commands := NewCommands()
+	rootCmd := commands.rootCmd
+	rootCmd.SetArgs([]string{
+		"new", "--name", "demo", "--author", "Demo", "--email", "demo@example.com",
+		"--main-dir", "demo", "--path", tempDir, "--template-dir", templateDir,
+	})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("new command failed: %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(tempDir, "demo", "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	if string(readme) != "# demo (team template)\n" {
+		t.Errorf("README.md was not rendered from the override template: %q", readme)
+	}
+
+	// Templates that are not overridden still come from the embedded set
+	if _, err := os.Stat(filepath.Join(tempDir, "demo", "pyproject.toml")); err != nil {
+		t.Errorf("Expected pyproject.toml from the embedded templates: %v", err)
+	}
+}
+
+func TestTemplatePackCommands(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Cleanup(func() { config.UnregisterPack("acme") })
+
+	source := t.TempDir()
+	packFiles := map[string]string{
+		"pack.yaml": `name: acme
+kinds:
+  - type: acme-service
+    files:
+      - template: service/README.md.j2
+        output: README.md
+      - output: "{{ main_dir_name }}/__init__.py"
+`,
+		"service/README.md.j2": "# {{ project_name }} (acme)\n",
+	}
+	for name, content := range packFiles {
+		path := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create pack dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write pack file: %v", err)
+		}
+	}
+
+	run := func(args ...string) error {
+		rootCmd := NewCommands().rootCmd
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+
+	if err := run("template", "lint", source); err != nil {
+		t.Fatalf("template lint of the pack failed: %v", err)
+	}
+	if err := run("template", "install", source); err != nil {
+		t.Fatalf("template install failed: %v", err)
+	}
+	if err := run("template", "list"); err != nil {
+		t.Fatalf("template list failed: %v", err)
+	}
+
+	// The pack's kind is accepted as a project type
+	projectsDir := t.TempDir()
+	if err := run("new", "--name", "demo", "--author", "Demo", "--email", "demo@example.com",
+		"--main-dir", "demo", "--type", "acme-service", "--path", projectsDir); err != nil {
+		t.Fatalf("new with a pack type failed: %v", err)
+	}
+	readme, err := os.ReadFile(filepath.Join(projectsDir, "demo", "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	if string(readme) != "# demo (acme)\n" {
+		t.Errorf("README.md = %q, want it rendered from the pack", readme)
+	}
+	if _, err := os.Stat(filepath.Join(projectsDir, "demo", "demo", "__init__.py")); err != nil {
+		t.Errorf("Expected demo/__init__.py from the pack manifest: %v", err)
+	}
+
+	if err := run("template", "remove", "acme"); err != nil {
+		t.Fatalf("template remove failed: %v", err)
+	}
+	if err := run("template", "remove", "acme"); err == nil {
+		t.Error("Expected error removing a pack that is no longer installed")
+	}
+
+	// Once removed, the type is no longer accepted
+	err = run("new", "--name", "demo2", "--author", "Demo", "--email", "demo@example.com",
+		"--main-dir", "demo2", "--type", "acme-service", "--path", projectsDir)
+	if err == nil || !strings.Contains(err.Error(), "--type") {
+		t.Errorf("new with a removed pack type error = %v, want --type error", err)
+	}
+}
+
+func TestTemplateLintCommand(t *testing.T) {
+	run := func(args ...string) error {
+		rootCmd := NewCommands().rootCmd
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+
+	if err := run("template", "lint"); err != nil {
+		t.Fatalf("template lint of the embedded templates failed: %v", err)
+	}
+
+	overrides := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(overrides, "basic"), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(overrides, "basic", "pyproject.toml.j2"), []byte("[project\n"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	err := run("template", "lint", overrides)
+	if err == nil || !strings.Contains(err.Error(), "template lint found 1 problems") {
+		t.Errorf("template lint error = %v, want one problem reported", err)
+	}
+}
+
+func TestRenderCommand(t *testing.T) {
+	run := func(args ...string) (string, string, error) {
+		rootCmd := NewCommands().rootCmd
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	output, warnings, err := run("render", "core/python-version.j2", "--set", "python_version=3.12")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if output != "3.12" || warnings != "" {
+		t.Errorf("render output = %q, warnings = %q; want the version and no warnings", output, warnings)
+	}
+
+	// --kind starts from the lint sample configuration, so nothing is undefined
+	output, warnings, err = run("render", "data-science/README.md.j2", "--kind", "data-science", "--set", "features.jupyter_kernel=true")
+	if err != nil {
+		t.Fatalf("render --kind failed: %v", err)
+	}
+	if !strings.Contains(output, "kernel was registered") || warnings != "" {
+		t.Errorf("render --kind output = %q, warnings = %q; want the registered kernel section", output, warnings)
+	}
+
+	_, warnings, err = run("render", "core/python-version.j2")
+	if err != nil || !strings.Contains(warnings, "undefined variables rendered as empty: python_version") {
+		t.Errorf("render without context: err = %v, warnings = %q; want python_version reported", err, warnings)
+	}
+
+	templateDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(templateDir, "broken.j2"), []byte("ok\n{{ x|no_such_filter }}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	_, _, err = run("render", "broken.j2", "--template-dir", templateDir)
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(templateDir, "broken.j2")+":2:") {
+		t.Errorf("render of a broken template error = %v, want its path and line", err)
+	}
+
+	if _, _, err := run("render", "core/python-version.j2", "--kind", "spaceship"); err == nil {
+		t.Error("render with an unknown --kind succeeded, want error")
+	}
+}
+
+func TestSetContextValue(t *testing.T) {
+	context := map[string]interface{}{"project": map[string]interface{}{"name": "old"}, "email": "a@b.c"}
+
+	for _, assignment := range []string{
+		"project.name=new",
+		"features.venv=true",
+		"deps.runtime=[httpx, rich]",
+		"python_version=3.10",
+		"empty=",
+	} {
+		if err := setContextValue(context, assignment); err != nil {
+			t.Fatalf("setContextValue(%q) failed: %v", assignment, err)
+		}
+	}
+
+	want := map[string]interface{}{
+		"project":        map[string]interface{}{"name": "new"},
+		"features":       map[string]interface{}{"venv": true},
+		"deps":           map[string]interface{}{"runtime": []string{"httpx", "rich"}},
+		"python_version": "3.10",
+		"empty":          "",
+		"email":          "a@b.c",
+	}
+	if !reflect.DeepEqual(context, want) {
+		t.Errorf("context = %v, want %v", context, want)
+	}
+
+	for _, assignment := range []string{"novalue", "=value", "email.domain=x"} {
+		if err := setContextValue(context, assignment); err == nil {
+			t.Errorf("setContextValue(%q) succeeded, want error", assignment)
+		}
+	}
+}
+
+func TestNewCommandPackQuestions(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Cleanup(func() { config.UnregisterPack("acme") })
+
+	source := t.TempDir()
+	packFiles := map[string]string{
+		"pack.yaml": `name: acme
+kinds:
+  - type: acme-service
+    questions:
+      - id: team_name
+        message: Owning team
+        required: true
+      - id: oncall_channel
+        message: On-call channel
+        default: "#oncall"
+      - id: tier
+        type: select
+        message: Deployment tier
+        choices: [dev, staging, production]
+    files:
+      - template: service/README.md.j2
+        output: README.md
+`,
+		"service/README.md.j2": "Owned by {{ answers.team_name }} ({{ answers.oncall_channel }}, {{ answers.tier }})\n",
+	}
+	for name, content := range packFiles {
+		path := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create pack dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write pack file: %v", err)
+		}
+	}
+
+	run := func(args ...string) error {
+		rootCmd := NewCommands().rootCmd
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+
+	if err := run("template", "install", source); err != nil {
+		t.Fatalf("template install failed: %v", err)
+	}
+
+	projectsDir := t.TempDir()
+	newArgs := []string{"new", "--name", "billing", "--author", "Demo", "--email", "demo@example.com",
+		"--main-dir", "billing", "--type", "acme-service", "--path", projectsDir}
+
+	err := run(newArgs...)
+	if err == nil || !strings.Contains(err.Error(), "missing required flag --answer team_name=<value>") {
+		t.Errorf("new without the required answer error = %v, want --answer team_name reported", err)
+	}
+
+	err = run(append(newArgs, "--answer", "tier=qa", "--answer", "team_name=payments")...)
+	if err == nil || !strings.Contains(err.Error(), "invalid value for --answer tier") {
+		t.Errorf("new with an invalid answer error = %v, want --answer tier reported", err)
+	}
+
+	if err := run(append(newArgs, "--answer", "team_name=payments", "--answer", "tier=production")...); err != nil {
+		t.Fatalf("new with answers failed: %v", err)
+	}
+	readme, err := os.ReadFile(filepath.Join(projectsDir, "billing", "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	if string(readme) != "Owned by payments (#oncall, production)\n" {
+		t.Errorf("README.md = %q, want the answers rendered", readme)
+	}
+}
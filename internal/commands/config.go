@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/prompts"
 	"github.com/Pradyothsp/pyinit/pkg/ui"
+	"github.com/spf13/cobra"
 )
 
 // handleShowConfig displays current configuration
@@ -11,23 +17,44 @@ func (c *Commands) handleShowConfig() {
 	fmt.Println("Current pyinit configuration:")
 	fmt.Printf("  Config file: %s\n", ui.GetConfigPath())
 
-	// Read banner status fresh each time
-	banner, err := ui.NewBanner()
+	cfg, err := loadSettings()
 	if err != nil {
-		fmt.Printf("  Banner enabled: unknown (error reading config: %v)\n", err)
+		fmt.Printf("  Error: %v\n", err)
+		fmt.Println("\nFix the config file, or run `pyinit config reset` to start over.")
+		return
+	}
+
+	fmt.Printf("  Banner enabled: %t\n", cfg.UI.ShowBanner)
+	if cfg.Templates.Dir != "" {
+		fmt.Printf("  Template directory: %s\n", cfg.Templates.Dir)
 	} else {
-		fmt.Printf("  Banner enabled: %t\n", banner.IsEnabled())
+		fmt.Println("  Template directory: (built-in templates only)")
 	}
 
+	fmt.Printf("  Default author: %s\n", valueOrNone(cfg.Defaults.Author, "git config user.name"))
+	fmt.Printf("  Default email: %s\n", valueOrNone(cfg.Defaults.Email, "git config user.email"))
+	fmt.Printf("  Default Python version: %s\n", valueOrNone(cfg.Defaults.PythonVersion, ""))
+	fmt.Printf("  Default project type: %s\n", valueOrNone(cfg.Defaults.ProjectType, ""))
+	fmt.Printf("  Remember last answers: %t\n", cfg.Defaults.RememberLast)
+	fmt.Printf("  Environment setup: %s\n", cfg.Setup.Environment)
+
 	fmt.Println("\nTo modify configuration:")
 	fmt.Println("  pyinit config banner enable    # Enable banner")
 	fmt.Println("  pyinit config banner disable   # Disable banner")
+	fmt.Println("  pyinit config set <key> <value> # Set a value, e.g. defaults.author")
+	fmt.Println("  pyinit config unset <key>       # Restore a setting's default")
 	fmt.Println("  pyinit config reset             # Reset to defaults")
 	fmt.Printf("  edit %s           # Edit config manually\n", ui.GetConfigPath())
 }
 
 // handleResetConfig resets configuration to defaults
 func (c *Commands) handleResetConfig() {
+	// Start from a fresh file, so a config file with invalid keys is replaced too
+	if err := os.Remove(ui.GetConfigPath()); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Error: Failed to reset configuration: %v\n", err)
+		return
+	}
+
 	// Create default config
 	defaultConfig := ui.DefaultConfig()
 
@@ -39,3 +66,139 @@ func (c *Commands) handleResetConfig() {
 	fmt.Println("✅ Configuration reset to defaults")
 	fmt.Printf("Config file: %s\n", ui.GetConfigPath())
 }
+
+// valueOrNone formats an optional setting for config show, naming the fallback if there is one
+func valueOrNone(value, fallback string) string {
+	switch {
+	case value != "":
+		return value
+	case fallback != "":
+		return fmt.Sprintf("(not set, using %s)", fallback)
+	}
+	return "(not set)"
+}
+
+// createConfigSettingCommands creates the commands that read and change single settings
+func (c *Commands) createConfigSettingCommands() []*cobra.Command {
+	keys := fmt.Sprintf("Settings: %s.", strings.Join(ui.Keys(), ", "))
+
+	setCmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a configuration value",
+		Long:  "Set a value in the config file. " + keys,
+		Example: `  pyinit config set defaults.author "Jane Doe"
+  pyinit config set defaults.remember_last true
+  pyinit config set setup.environment always`,
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runConfigSet,
+	}
+
+	getCmd := &cobra.Command{
+		Use:           "get <key>",
+		Short:         "Print a configuration value",
+		Long:          "Print a value from the config file. " + keys,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runConfigGet,
+	}
+
+	unsetCmd := &cobra.Command{
+		Use:           "unset <key>",
+		Short:         "Restore a configuration value to its default",
+		Long:          "Restore a value in the config file to its default. " + keys,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runConfigUnset,
+	}
+
+	return []*cobra.Command{setCmd, getCmd, unsetCmd}
+}
+
+// loadSettings loads the config file, starting from the defaults when it does not exist yet
+func loadSettings() (*ui.Config, error) {
+	settings, err := ui.LoadConfig()
+	if errors.Is(err, ui.ErrNoConfig) {
+		return ui.DefaultConfig(), nil
+	}
+	return settings, err
+}
+
+// runConfigSet validates and stores a setting
+func (c *Commands) runConfigSet(cmd *cobra.Command, args []string) error {
+	key, err := ui.ResolveKey(args[0])
+	if err != nil {
+		return err
+	}
+	value := args[1]
+
+	// Project types may come from installed template packs
+	c.registerTemplatePacks()
+	if err := prompts.ValidateSetting(key, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if err := settings.Set(key, value); err != nil {
+		return err
+	}
+	if err := settings.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ %s set to %s\n", key, value)
+	return nil
+}
+
+// runConfigGet prints a setting
+func (c *Commands) runConfigGet(cmd *cobra.Command, args []string) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+
+	value, err := settings.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), value)
+	return nil
+}
+
+// runConfigUnset restores a setting to its default
+func (c *Commands) runConfigUnset(cmd *cobra.Command, args []string) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if err := settings.Unset(args[0]); err != nil {
+		return err
+	}
+	if err := settings.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ %s restored to its default\n", args[0])
+	return nil
+}
+
+// rememberAnswers stores the answers of a run as the defaults of the next one, when
+// remember_last is on
+func rememberAnswers(settings *ui.Config, cfg *config.ProjectConfig) error {
+	if !settings.Defaults.RememberLast {
+		return nil
+	}
+
+	settings.Defaults.Author = cfg.UserName
+	settings.Defaults.Email = cfg.Email
+	settings.Defaults.PythonVersion = cfg.PythonVersion
+	settings.Defaults.ProjectType = cfg.ProjectType
+	return settings.Save()
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/Pradyothsp/pyinit/internal/config"
 	"github.com/Pradyothsp/pyinit/internal/generator"
@@ -26,15 +27,62 @@ func (c *Commands) runInteractive(cmd *cobra.Command, args []string) {
 		fmt.Printf("Warning: Banner display failed: %v\n", err)
 	}
 
-	// Collect user information
-	cfg, err := prompts.CollectProjectInfo()
+	// Offer the project kinds of installed template packs
+	c.registerTemplatePacks()
+
+	// Load recorded answers, if any
+	var answers *prompts.Answers
+	if answersPath, _ := cmd.Flags().GetString("answers"); answersPath != "" {
+		loaded, err := prompts.LoadAnswers(answersPath)
+		if err != nil {
+			fmt.Printf("Error: Failed to load answers: %v\n", err)
+			return
+		}
+		answers = loaded
+	}
+
+	// Collect user information, offering the defaults from the config file and git
+	settings, err := loadSettings()
+	if err != nil {
+		fmt.Printf("Warning: Ignoring the config file: %v\n", err)
+		settings = ui.DefaultConfig()
+	}
+	defaults := prompts.UserDefaults(settings)
+	cfg, err := prompts.CollectProjectInfo(answers, defaults)
 	if err != nil {
 		fmt.Printf("Error: Failed to collect project info: %v\n", err)
 		return
 	}
 
+	// Ask about dependencies, the Jupyter kernel and the environment before rendering, so
+	// templates can see the choices
+	if err := c.collectChoices(cfg, answers, settings); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Show the answers, the choices and the location, letting the user change any of them
+	if err := prompts.ReviewProjectInfo(cfg, answers, defaults, reviewChoices()); err != nil {
+		fmt.Printf("Error: Failed to review project info: %v\n", err)
+		return
+	}
+
+	gen, err := c.newGenerator(cmd, settings)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Preview instead of generating when asked to
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		showContent, _ := cmd.Flags().GetBool("show-content")
+		if err := c.printDryRun(gen, cfg, showContent); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		return
+	}
+
 	// Generate project
-	gen := generator.New()
 	if err := gen.GenerateProject(cfg); err != nil {
 		fmt.Printf("Error: Failed to generate project: %v\n", err)
 		return
@@ -42,18 +90,67 @@ func (c *Commands) runInteractive(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("✅ Project '%s' created successfully at: %s\n", cfg.ProjectName, cfg.ProjectPath)
 
-	// Handle FastAPI dependencies setup (only for FastAPI projects)
-	if err := c.handleFastAPIDependencies(cfg); err != nil {
-		fmt.Printf("Warning: Failed to setup FastAPI dependencies: %v\n", err)
+	// Offer this run's answers next time, when remember_last is on
+	if err := rememberAnswers(settings, cfg); err != nil {
+		fmt.Printf("Warning: Failed to remember answers: %v\n", err)
+	}
+
+	// Handle optional dependencies (FastAPI, Flask and data-science projects)
+	if err := c.handleDependencies(cfg); err != nil {
+		fmt.Printf("Warning: Failed to setup dependencies: %v\n", err)
+	}
+
+	// Handle Jupyter kernel registration (only for data-science projects)
+	if err := c.handleKernelRegistration(cfg); err != nil {
+		fmt.Printf("Warning: Failed to register Jupyter kernel: %v\n", err)
 	}
 
 	// Handle environment setup
-	if err := c.handleEnvironmentSetup(cfg.ProjectPath); err != nil {
+	if err := c.handleEnvironmentSetup(cfg); err != nil {
 		fmt.Printf("Warning: Failed to setup environment: %v\n", err)
 		return
 	}
 }
 
+// newGenerator creates a generator using the template directory from --template-dir,
+// falling back to templates.dir in settings. Callers that have not loaded the config
+// file pass nil, and it is loaded here; a config file that does not load is an error.
+func (c *Commands) newGenerator(cmd *cobra.Command, settings *ui.Config) (*generator.Generator, error) {
+	gen := generator.New()
+
+	templateDir, _ := cmd.Flags().GetString("template-dir")
+	if templateDir == "" {
+		if settings == nil {
+			loaded, err := loadSettings()
+			if err != nil {
+				return nil, err
+			}
+			settings = loaded
+		}
+		templateDir = settings.Templates.Dir
+	}
+	if templateDir == "" {
+		return gen, nil
+	}
+
+	if err := gen.SetTemplateDir(templateDir); err != nil {
+		return nil, fmt.Errorf("failed to use template directory: %w", err)
+	}
+	return gen, nil
+}
+
+// printDryRun renders the project in memory and prints what would be created
+func (c *Commands) printDryRun(gen *generator.Generator, cfg *config.ProjectConfig, showContent bool) error {
+	plan, err := gen.PlanProject(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to plan project: %w", err)
+	}
+
+	fmt.Printf("🔍 Dry run: nothing will be written. Project '%s' would be created at: %s\n\n", cfg.ProjectName, cfg.ProjectPath)
+	plan.Print(os.Stdout, showContent)
+	return nil
+}
+
 // showBannerIfEnabled displays banner if enabled in config
 func (c *Commands) showBannerIfEnabled() error {
 	banner, err := ui.NewBanner()
@@ -66,41 +163,174 @@ func (c *Commands) showBannerIfEnabled() error {
 	return nil
 }
 
-// handleFastAPIDependencies manages FastAPI dependency installation
-func (c *Commands) handleFastAPIDependencies(cfg *config.ProjectConfig) error {
-	// Only handle FastAPI dependencies for FastAPI projects
-	if cfg.ProjectType != "web" || cfg.WebFramework != "fastapi" {
-		return nil
+// dependencySet describes the optional dependencies offered for a kind of project
+type dependencySet struct {
+	ask     func() ([]string, error)
+	install func(projectPath string, selectedDeps []string) error
+}
+
+// dependencySetFor returns the dependency selection offered for cfg, if any
+func dependencySetFor(cfg *config.ProjectConfig) (dependencySet, bool) {
+	switch {
+	case cfg.ProjectType == "web" && cfg.WebFramework == "fastapi":
+		return dependencySet{ask: prompts.AskForFastAPIDependencies, install: setup.FastAPIDependencies}, true
+	case cfg.ProjectType == "web" && cfg.WebFramework == "flask":
+		return dependencySet{ask: prompts.AskForFlaskDependencies, install: setup.FlaskDependencies}, true
+	case cfg.ProjectType == "data-science":
+		return dependencySet{ask: prompts.AskForDataScienceDependencies, install: setup.DataScienceDependencies}, true
 	}
+	return dependencySet{}, false
+}
 
-	// Ask user to select FastAPI dependencies
-	selectedDeps, err := prompts.AskForFastAPIDependencies()
-	if err != nil {
-		return fmt.Errorf("failed to prompt for FastAPI dependencies: %w", err)
+// collectChoices asks whether to install optional dependencies, register a Jupyter kernel
+// and set up the environment, unless the answers file already recorded the choices or
+// setup.environment in the config file decides the environment setup
+func (c *Commands) collectChoices(cfg *config.ProjectConfig, answers *prompts.Answers, settings *ui.Config) error {
+	if deps, ok := dependencySetFor(cfg); ok {
+		selectedDeps, ok := answers.Dependencies()
+		if !ok {
+			var err error
+			selectedDeps, err = deps.ask()
+			if err != nil {
+				return fmt.Errorf("failed to prompt for dependencies: %w", err)
+			}
+		}
+		cfg.Dependencies = selectedDeps
 	}
 
-	if len(selectedDeps) == 0 {
+	if cfg.ProjectType == "data-science" {
+		registerKernel, ok := answers.RegisterKernel()
+		if !ok {
+			var err error
+			registerKernel, err = prompts.AskForKernelRegistration(config.SanitizeProjectName(cfg.ProjectName))
+			if err != nil {
+				return fmt.Errorf("failed to prompt for kernel registration: %w", err)
+			}
+		}
+		cfg.RegisterKernel = registerKernel
+	}
+
+	setupEnv, ok := answers.SetupEnvironment()
+	if !ok && settings.Setup.Environment != "ask" {
+		setupEnv, ok = settings.Setup.Environment == "always", true
+	}
+	if !ok {
+		var err error
+		setupEnv, err = prompts.AskForEnvironmentSetup()
+		if err != nil {
+			return fmt.Errorf("failed to prompt for environment setup: %w", err)
+		}
+	}
+	cfg.SetupEnvironment = setupEnv
+
+	return nil
+}
+
+// reviewChoices describes the choices collectChoices makes, so the review screen can show
+// and change them
+func reviewChoices() []prompts.Choice {
+	return []prompts.Choice{
+		{
+			Label: "Dependencies",
+			Scope: func(cfg *config.ProjectConfig) string {
+				if _, ok := dependencySetFor(cfg); !ok {
+					return ""
+				}
+				return cfg.ProjectType + " " + cfg.WebFramework
+			},
+			Value: func(cfg *config.ProjectConfig) interface{} { return cfg.Dependencies },
+			Ask: func(cfg *config.ProjectConfig) error {
+				deps, _ := dependencySetFor(cfg)
+				selectedDeps, err := deps.ask()
+				if err != nil {
+					return fmt.Errorf("failed to prompt for dependencies: %w", err)
+				}
+				cfg.Dependencies = selectedDeps
+				return nil
+			},
+			Clear: func(cfg *config.ProjectConfig) { cfg.Dependencies = nil },
+		},
+		{
+			Label: "Jupyter kernel",
+			Scope: func(cfg *config.ProjectConfig) string {
+				if cfg.ProjectType != "data-science" {
+					return ""
+				}
+				return cfg.ProjectType
+			},
+			Value: func(cfg *config.ProjectConfig) interface{} { return cfg.RegisterKernel },
+			Ask: func(cfg *config.ProjectConfig) error {
+				registerKernel, err := prompts.AskForKernelRegistration(config.SanitizeProjectName(cfg.ProjectName))
+				if err != nil {
+					return fmt.Errorf("failed to prompt for kernel registration: %w", err)
+				}
+				cfg.RegisterKernel = registerKernel
+				return nil
+			},
+			Clear: func(cfg *config.ProjectConfig) { cfg.RegisterKernel = false },
+		},
+		{
+			Label: "Set up environment",
+			Scope: func(cfg *config.ProjectConfig) string { return "environment" },
+			Value: func(cfg *config.ProjectConfig) interface{} { return cfg.SetupEnvironment },
+			Ask: func(cfg *config.ProjectConfig) error {
+				setupEnv, err := prompts.AskForEnvironmentSetup()
+				if err != nil {
+					return fmt.Errorf("failed to prompt for environment setup: %w", err)
+				}
+				cfg.SetupEnvironment = setupEnv
+				return nil
+			},
+			Clear: func(cfg *config.ProjectConfig) { cfg.SetupEnvironment = false },
+		},
+	}
+}
+
+// handleDependencies installs the optional dependencies selected for project types that offer them
+func (c *Commands) handleDependencies(cfg *config.ProjectConfig) error {
+	deps, ok := dependencySetFor(cfg)
+	if !ok {
+		return nil
+	}
+
+	if len(cfg.Dependencies) == 0 {
 		fmt.Println("No dependencies selected, skipping installation.")
 		return nil
 	}
 
 	// Install selected dependencies
-	return setup.FastAPIDependencies(cfg.ProjectPath, selectedDeps)
+	return deps.install(cfg.ProjectPath, cfg.Dependencies)
 }
 
-// handleEnvironmentSetup manages the development environment setup
-func (c *Commands) handleEnvironmentSetup(projectPath string) error {
-	// Ask user if they want to set up environment
-	setupEnv, err := prompts.AskForEnvironmentSetup()
-	if err != nil {
-		return fmt.Errorf("failed to prompt for environment setup: %w", err)
+// handleKernelRegistration registers a Jupyter kernel for data-science projects when chosen
+func (c *Commands) handleKernelRegistration(cfg *config.ProjectConfig) error {
+	if cfg.ProjectType != "data-science" {
+		return nil
 	}
 
-	if !setupEnv {
-		setup.ShowManualInstructions(projectPath)
+	kernelName := config.SanitizeProjectName(cfg.ProjectName)
+	displayName := kernelDisplayName(cfg)
+
+	if !cfg.RegisterKernel {
+		setup.ShowManualKernelInstructions(cfg.ProjectPath, kernelName, displayName)
+		return nil
+	}
+
+	return setup.RegisterKernel(cfg.ProjectPath, kernelName, displayName)
+}
+
+// kernelDisplayName is the name Jupyter shows for the project's kernel
+func kernelDisplayName(cfg *config.ProjectConfig) string {
+	return fmt.Sprintf("Python (%s)", cfg.ProjectName)
+}
+
+// handleEnvironmentSetup sets up the development environment when chosen
+func (c *Commands) handleEnvironmentSetup(cfg *config.ProjectConfig) error {
+	if !cfg.SetupEnvironment {
+		setup.ShowManualInstructions(cfg.ProjectPath, cfg.ProjectType)
 		return nil
 	}
 
 	// Set up the development environment
-	return setup.DevDependencies(projectPath)
+	return setup.DevDependencies(cfg.ProjectPath, cfg.ProjectType)
 }
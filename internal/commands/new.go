@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/prompts"
+	"github.com/Pradyothsp/pyinit/internal/setup"
+	"github.com/Pradyothsp/pyinit/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// flagForQuestion maps prompt question IDs to the `new` flags that answer them
+var flagForQuestion = map[string]string{
+	"username":      "author",
+	"email":         "email",
+	"projectname":   "name",
+	"projecttype":   "type",
+	"webframework":  "framework",
+	"cliframework":  "framework",
+	"maindirname":   "main-dir",
+	"description":   "description",
+	"pythonversion": "python",
+}
+
+// createNewCommand creates the non-interactive `new` command
+func (c *Commands) createNewCommand() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "new",
+		Short: "Create a project from flags without prompting",
+		Long:  "Create a Python project non-interactively. Every answer comes from flags, so it is safe to use in CI and scripts.",
+		Example: `  pyinit new --name my-app --author "Jane Doe" --email jane@example.com
+  pyinit new --name api --type web --framework fastapi --author "Jane Doe" --email jane@example.com --main-dir api --path ./services`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runNew,
+	}
+
+	flags := newCmd.Flags()
+	flags.String("name", "", "Project name")
+	flags.String("type", "", fmt.Sprintf("Project type (%s, or a type from an installed template pack)", joinOptions(config.ProjectTypes())))
+	flags.String("framework", "", fmt.Sprintf("Framework for web projects (%s) or cli projects (%s)",
+		joinOptions(config.WebFrameworks()), joinOptions(config.CLIFrameworks())))
+	flags.String("main-dir", "", "Main package directory name (default: derived from --name, e.g. my_app for \"My App\")")
+	flags.String("python", "", fmt.Sprintf("Python version, 3.%d to 3.%d", validation.MinPythonMinor, validation.MaxPythonMinor))
+	flags.String("author", "", "Author name")
+	flags.String("email", "", "Author email")
+	flags.String("description", "", "Project description")
+	flags.String("path", "", "Parent directory to create the project in (default: current directory)")
+	flags.Bool("force", false, "Continue even if the project directory already exists")
+	flags.StringSlice("deps", nil, "Dependencies to install after generation (FastAPI, Flask and data-science projects)")
+	flags.Bool("setup-env", false, "Set up the development environment with uv after generation")
+	flags.Bool("register-kernel", false, "Register a Jupyter kernel named after the project (data-science projects)")
+	flags.StringArray("answer", nil, "Answer to a question a template pack asks for its project type, as id=value (repeatable)")
+	addDryRunFlags(newCmd)
+	addTemplateDirFlag(newCmd)
+
+	return newCmd
+}
+
+// runNew creates a project purely from flags
+func (c *Commands) runNew(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+
+	// Accept the project kinds of installed template packs
+	c.registerTemplatePacks()
+
+	cfg := &config.ProjectConfig{}
+	cfg.ProjectName, _ = flags.GetString("name")
+	cfg.ProjectType, _ = flags.GetString("type")
+	cfg.MainDirName, _ = flags.GetString("main-dir")
+	cfg.PythonVersion, _ = flags.GetString("python")
+	cfg.UserName, _ = flags.GetString("author")
+	cfg.Email, _ = flags.GetString("email")
+	cfg.ProjectDescription, _ = flags.GetString("description")
+
+	// --framework answers whichever framework question the project type asks
+	if framework, _ := flags.GetString("framework"); framework != "" {
+		switch cfg.ProjectType {
+		case "web":
+			cfg.WebFramework = framework
+		case "cli":
+			cfg.CLIFramework = framework
+		default:
+			return fmt.Errorf("--framework requires --type web or --type cli")
+		}
+	}
+
+	// --answer answers the extra questions of template pack kinds
+	answers := map[string]interface{}{}
+	assignments, _ := flags.GetStringArray("answer")
+	for _, assignment := range assignments {
+		id, value, found := strings.Cut(assignment, "=")
+		if !found || id == "" {
+			return fmt.Errorf("invalid --answer %q: want id=value", assignment)
+		}
+		answers[id] = value
+	}
+	if err := prompts.ApplyAnswers(cfg, answers); err != nil {
+		return flagError(err)
+	}
+
+	prompts.ApplyDefaults(cfg)
+
+	if err := prompts.ValidateConfig(cfg); err != nil {
+		return flagError(err)
+	}
+
+	deps, _ := flags.GetStringSlice("deps")
+	dependencies, offersDeps := dependencySetFor(cfg)
+	if len(deps) > 0 && !offersDeps {
+		return fmt.Errorf("--deps is only supported for FastAPI, Flask and data-science projects")
+	}
+
+	registerKernel, _ := flags.GetBool("register-kernel")
+	if registerKernel && cfg.ProjectType != "data-science" {
+		return fmt.Errorf("--register-kernel is only supported for data-science projects")
+	}
+
+	cfg.Dependencies = deps
+	cfg.RegisterKernel = registerKernel
+	cfg.SetupEnvironment, _ = flags.GetBool("setup-env")
+
+	parentDir, _ := flags.GetString("path")
+	if parentDir == "" {
+		parentDir = "."
+	}
+	absParent, err := filepath.Abs(parentDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve --path: %w", err)
+	}
+	cfg.ProjectPath = filepath.Join(absParent, config.SanitizeProjectName(cfg.ProjectName))
+
+	gen, err := c.newGenerator(cmd, nil)
+	if err != nil {
+		return err
+	}
+
+	if dryRun, _ := flags.GetBool("dry-run"); dryRun {
+		showContent, _ := flags.GetBool("show-content")
+		return c.printDryRun(gen, cfg, showContent)
+	}
+
+	force, _ := flags.GetBool("force")
+	gen.SetDirectoryConfirmation(func(path string) (bool, error) {
+		if _, err := os.Stat(path); err == nil && !force {
+			return false, fmt.Errorf("directory %s already exists (use --force to continue anyway)", path)
+		}
+		return true, nil
+	})
+
+	if err := gen.GenerateProject(cfg); err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	fmt.Printf("✅ Project '%s' created successfully at: %s\n", cfg.ProjectName, cfg.ProjectPath)
+
+	if len(cfg.Dependencies) > 0 {
+		if err := dependencies.install(cfg.ProjectPath, cfg.Dependencies); err != nil {
+			return fmt.Errorf("failed to install dependencies: %w", err)
+		}
+	}
+
+	if cfg.RegisterKernel {
+		kernelName := config.SanitizeProjectName(cfg.ProjectName)
+		if err := setup.RegisterKernel(cfg.ProjectPath, kernelName, kernelDisplayName(cfg)); err != nil {
+			return fmt.Errorf("failed to register kernel: %w", err)
+		}
+	}
+
+	if cfg.SetupEnvironment {
+		if err := setup.DevDependencies(cfg.ProjectPath, cfg.ProjectType); err != nil {
+			return fmt.Errorf("failed to setup environment: %w", err)
+		}
+	} else {
+		setup.ShowManualInstructions(cfg.ProjectPath, cfg.ProjectType)
+	}
+
+	return nil
+}
+
+// flagError rewrites a prompt validation error in terms of the flag that supplies the value
+func flagError(err error) error {
+	var validationErr *prompts.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	flag, ok := flagForQuestion[validationErr.QuestionID]
+	if !ok {
+		// Any other question is one a template pack declares
+		if errors.Is(validationErr, prompts.ErrRequired) {
+			return fmt.Errorf("missing required flag --answer %s=<value>", validationErr.QuestionID)
+		}
+		return fmt.Errorf("invalid value for --answer %s: %w", validationErr.QuestionID, validationErr.Err)
+	}
+
+	if errors.Is(validationErr, prompts.ErrRequired) {
+		return fmt.Errorf("missing required flag --%s", flag)
+	}
+
+	return fmt.Errorf("invalid value for --%s: %w", flag, validationErr.Err)
+}
+
+// joinOptions formats a list of choices for flag help text
+func joinOptions(options []string) string {
+	return strings.Join(options, ", ")
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+// setupRenderCommand adds the command that renders a single template for template authors
+func (c *Commands) setupRenderCommand() {
+	c.rootCmd.AddCommand(c.createRenderCommand())
+}
+
+// createRenderCommand creates the render command
+func (c *Commands) createRenderCommand() *cobra.Command {
+	renderCmd := &cobra.Command{
+		Use:   "render <template>",
+		Short: "Render a single template to stdout",
+		Long: `Render one template with a context built from --set values, to debug templates while writing them.
+Templates are named relative to the template root, e.g. web/fastapi/main.py.j2.`,
+		Example: `  pyinit render core/python-version.j2 --set python_version=3.12
+  pyinit render web/fastapi/README.md.j2 --kind web/fastapi --set project_name="My API"
+  pyinit render basic/README.md.j2 --template-dir ./templates --set features.venv=true --set deps.runtime=[httpx,rich]`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runRender,
+	}
+
+	flags := renderCmd.Flags()
+	flags.StringArray("set", nil, "Context value as key=value; dotted keys set nested values, true/false are booleans and [a,b] is a list")
+	flags.String("kind", "", "Start from the sample configuration `pyinit template lint` renders this kind with, e.g. web/fastapi")
+	addTemplateDirFlag(renderCmd)
+
+	return renderCmd
+}
+
+// runRender renders one template to stdout, warning about variables the context lacks
+func (c *Commands) runRender(cmd *cobra.Command, args []string) error {
+	gen, err := c.newGenerator(cmd, nil)
+	if err != nil {
+		return err
+	}
+
+	context := map[string]interface{}{}
+	kind := config.ProjectKind{}
+	if kindID, _ := cmd.Flags().GetString("kind"); kindID != "" {
+		c.registerTemplatePacks()
+		found, ok := findKind(kindID)
+		if !ok {
+			return fmt.Errorf("unknown project kind %q", kindID)
+		}
+		kind = found
+		context = lint.Samples(kind)[0].Config.TemplateContext()
+	}
+
+	values, _ := cmd.Flags().GetStringArray("set")
+	for _, value := range values {
+		if err := setContextValue(context, value); err != nil {
+			return err
+		}
+	}
+
+	engine, err := gen.EngineFor(kind)
+	if err != nil {
+		return err
+	}
+
+	output, err := engine.RenderTemplate(args[0], context)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), output)
+
+	if undefined, err := engine.UndefinedVariables(args[0], context); err == nil && len(undefined) > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: undefined variables rendered as empty: %s\n", strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+// findKind returns the registered kind with the given ID, e.g. "web/fastapi"
+func findKind(id string) (config.ProjectKind, bool) {
+	for _, kind := range config.ProjectKinds() {
+		if kind.ID() == id {
+			return kind, true
+		}
+	}
+	return config.ProjectKind{}, false
+}
+
+// setContextValue applies a --set key=value assignment to context. Dotted keys such as
+// project.name set values in nested maps, creating them as needed.
+func setContextValue(context map[string]interface{}, assignment string) error {
+	key, value, found := strings.Cut(assignment, "=")
+	if !found || key == "" {
+		return fmt.Errorf("invalid --set %q: want key=value", assignment)
+	}
+
+	parts := strings.Split(key, ".")
+	current := context
+	for _, part := range parts[:len(parts)-1] {
+		next, exists := current[part]
+		if !exists {
+			nested := map[string]interface{}{}
+			current[part] = nested
+			current = nested
+			continue
+		}
+		nested, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid --set %q: %s is not a map", assignment, part)
+		}
+		current = nested
+	}
+
+	current[parts[len(parts)-1]] = parseContextValue(value)
+	return nil
+}
+
+// parseContextValue converts a --set value: true and false are booleans, [a,b] is a
+// list of strings and everything else, numbers included, stays a string
+func parseContextValue(value string) interface{} {
+	switch {
+	case value == "true":
+		return true
+	case value == "false":
+		return false
+	case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+		items := []string{}
+		for _, item := range strings.Split(value[1:len(value)-1], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return value
+}
@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/lint"
+	"github.com/Pradyothsp/pyinit/internal/packs"
+	"github.com/spf13/cobra"
+)
+
+// setupTemplateCommands adds the commands that manage template packs
+func (c *Commands) setupTemplateCommands() {
+	templateCmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage template packs",
+		Long: fmt.Sprintf(`Install, list and remove template packs. A pack is a directory with a %s manifest
+listing the project kinds it adds and the templates they render. Installed packs are
+offered next to the built-in project types.`, packs.ManifestFile),
+	}
+
+	templateCmd.AddCommand(c.createTemplateInstallCommand())
+	templateCmd.AddCommand(c.createTemplateListCommand())
+	templateCmd.AddCommand(c.createTemplateRemoveCommand())
+	templateCmd.AddCommand(c.createTemplateLintCommand())
+
+	c.rootCmd.AddCommand(templateCmd)
+}
+
+// createTemplateInstallCommand creates the template install command
+func (c *Commands) createTemplateInstallCommand() *cobra.Command {
+	installCmd := &cobra.Command{
+		Use:   "install <path|archive.tar.gz|bare-repo>",
+		Short: "Install a template pack",
+		Long:  "Install a template pack from a directory, a .tar.gz archive or a local bare git repository. Packs are never fetched over the network: download or clone remote packs first.",
+		Example: `  pyinit template install ./acme-templates
+  pyinit template install acme-templates-1.0.0.tar.gz
+  pyinit template install /srv/git/acme-templates.git
+  pyinit template install file:///srv/git/acme-templates.git`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runTemplateInstall,
+	}
+
+	installCmd.Flags().Bool("force", false, "Replace the pack if it is already installed")
+	return installCmd
+}
+
+// createTemplateListCommand creates the template list command
+func (c *Commands) createTemplateListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "list",
+		Short:         "List installed template packs",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runTemplateList,
+	}
+}
+
+// createTemplateRemoveCommand creates the template remove command
+func (c *Commands) createTemplateRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "remove <name>",
+		Short:         "Remove an installed template pack",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runTemplateRemove,
+	}
+}
+
+// createTemplateLintCommand creates the template lint command
+func (c *Commands) createTemplateLintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint [dir]",
+		Short: "Check templates by rendering them with sample configurations",
+		Long: `Render every project type with a matrix of sample configurations and report
+templates that fail to parse, use undefined variables, render invalid TOML, YAML,
+JSON or Python, or hard-code values such as the Python version that should come
+from the template context.
+
+Without an argument the embedded templates and installed template packs are linted.
+A directory with a ` + packs.ManifestFile + ` is linted as a template pack; any other directory
+is layered over the embedded templates, like pyinit new --template-dir.`,
+		Example: `  pyinit template lint
+  pyinit template lint ./my-templates
+  pyinit template lint ./acme-templates`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.runTemplateLint,
+	}
+}
+
+// runTemplateInstall installs the pack named by the argument
+func (c *Commands) runTemplateInstall(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	pack, err := packs.Install(args[0], force)
+	if err != nil {
+		return fmt.Errorf("failed to install template pack: %w", err)
+	}
+
+	fmt.Printf("✅ Installed template pack '%s' at: %s\n", pack.Name, pack.Dir)
+	fmt.Printf("Project types: %s\n", strings.Join(kindIDs(pack), ", "))
+	return nil
+}
+
+// runTemplateList prints the installed packs and the kinds they add
+func (c *Commands) runTemplateList(cmd *cobra.Command, args []string) error {
+	installed, err := packs.List()
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	dir, dirErr := packs.Dir()
+	if dirErr != nil {
+		return dirErr
+	}
+
+	if len(installed) == 0 {
+		fmt.Printf("No template packs installed in %s\n", dir)
+		fmt.Println("\nInstall one with:")
+		fmt.Println("  pyinit template install <path|archive.tar.gz|bare-repo>")
+		return nil
+	}
+
+	fmt.Printf("Template packs in %s:\n", dir)
+	for _, pack := range installed {
+		fmt.Printf("\n  %s", pack.Name)
+		if pack.Version != "" {
+			fmt.Printf(" %s", pack.Version)
+		}
+		if pack.Description != "" {
+			fmt.Printf(" - %s", pack.Description)
+		}
+		fmt.Println()
+
+		for _, kind := range pack.ProjectKinds() {
+			if kind.Description != "" {
+				fmt.Printf("    %s: %s\n", kind.ID(), kind.Description)
+			} else {
+				fmt.Printf("    %s\n", kind.ID())
+			}
+		}
+	}
+	return nil
+}
+
+// runTemplateRemove uninstalls the named pack
+func (c *Commands) runTemplateRemove(cmd *cobra.Command, args []string) error {
+	if err := packs.Remove(args[0]); err != nil {
+		return err
+	}
+
+	config.UnregisterPack(args[0])
+	fmt.Printf("✅ Removed template pack '%s'\n", args[0])
+	return nil
+}
+
+// runTemplateLint lints the embedded templates, a template directory or a template pack
+func (c *Commands) runTemplateLint(cmd *cobra.Command, args []string) error {
+	linter := lint.New()
+
+	var kinds []config.ProjectKind
+	switch {
+	case len(args) == 0:
+		c.registerTemplatePacks()
+		kinds = config.ProjectKinds()
+	case isTemplatePack(args[0]):
+		pack, err := packs.Load(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to load template pack: %w", err)
+		}
+		kinds = pack.ProjectKinds()
+		for _, kind := range kinds {
+			if err := config.RegisterKind(kind); err != nil {
+				return err
+			}
+		}
+	default:
+		linter.SetTemplateDir(args[0])
+		kinds = config.ProjectKinds()
+	}
+
+	report, err := linter.Lint(kinds)
+	if err != nil {
+		return fmt.Errorf("failed to lint templates: %w", err)
+	}
+
+	fmt.Printf("Rendered %d project types with %d sample configurations\n", report.Kinds, report.Samples)
+	for _, note := range report.Notes {
+		fmt.Printf("Note: %s\n", note)
+	}
+
+	if len(report.Issues) == 0 {
+		fmt.Println("✅ No template problems found")
+		return nil
+	}
+
+	fmt.Printf("\n❌ Found %d template problems:\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		fmt.Printf("  - %s\n", issue)
+	}
+	return fmt.Errorf("template lint found %d problems", len(report.Issues))
+}
+
+// isTemplatePack reports whether dir holds a template pack rather than template overrides
+func isTemplatePack(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, packs.ManifestFile))
+	return err == nil
+}
+
+// registerTemplatePacks offers the kinds of installed packs next to the built-in ones
+func (c *Commands) registerTemplatePacks() {
+	if err := packs.Register(); err != nil {
+		fmt.Printf("Warning: Some template packs could not be loaded: %v\n", err)
+	}
+}
+
+// kindIDs returns the IDs of the kinds a pack adds
+func kindIDs(pack *packs.Pack) []string {
+	var ids []string
+	for _, kind := range pack.ProjectKinds() {
+		ids = append(ids, kind.ID())
+	}
+	return ids
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 // ProjectConfig holds all the configuration for a Python project
@@ -12,24 +14,27 @@ type ProjectConfig struct {
 	ProjectDescription string
 	ProjectType        string
 	WebFramework       string
+	CLIFramework       string
 	ProjectPath        string
 	MainDirName        string
 	PythonVersion      string
-}
 
-// ProjectTypes returns available project types
-func ProjectTypes() []string {
-	return []string{"basic", "cli", "web", "library", "data-science"}
-}
+	// Choices acted on after the files are written, exposed to templates as deps.* and features.*
+	Dependencies     []string // Optional runtime dependencies selected for the project
+	SetupEnvironment bool     // Whether the virtual environment is created and DevDependencies installed
+	RegisterKernel   bool     // Whether a Jupyter kernel is registered (data-science projects)
 
-func WebFrameworks() []string {
-	return []string{"fastapi", "flask", "django"}
+	// Answers to the questions a template pack declares for the kind, by question ID
+	Answers map[string]interface{}
 }
 
+// DevDependencies are the development tools added to every project when its environment is set up
+var DevDependencies = []string{"ruff", "pyright"}
+
 // SanitizeProjectName converts the project name to a valid directory name
 func SanitizeProjectName(name string) string {
-	// Replace spaces with hyphens and convert to lowercase
-	sanitized := strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	// Replace spaces with hyphens, spell accented letters in ASCII and convert to lowercase
+	sanitized := strings.ToLower(Transliterate(strings.ReplaceAll(name, " ", "-")))
 
 	// Remove any characters that aren't alphanumeric, hyphens, or underscores
 	result := ""
@@ -42,19 +47,131 @@ func SanitizeProjectName(name string) string {
 	return result
 }
 
-// TemplateContext returns a map for template rendering
+// DefaultMainDirName derives a PEP 8 package name from the project name: lowercase ASCII
+// words joined by underscores, so "My-App 2" becomes "my_app_2" and "Ünïcode App" becomes
+// "unicode_app". A leading digit gets an underscore in front: "2048 Game" becomes "_2048_game".
+func DefaultMainDirName(projectName string) string {
+	var b strings.Builder
+	separate := false
+	for _, char := range strings.ToLower(Transliterate(projectName)) {
+		if (char < 'a' || char > 'z') && (char < '0' || char > '9') {
+			separate = b.Len() > 0
+			continue
+		}
+		if separate {
+			b.WriteRune('_')
+			separate = false
+		}
+		b.WriteRune(char)
+	}
+
+	name := b.String()
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// asciiLetters spell the accented Latin letters Transliterate replaces, by lowercase letter
+var asciiLetters = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'æ': "ae", 'ç': "c", 'ć': "c", 'č': "c", 'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ğ': "g", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'į': "i", 'ı': "i",
+	'ł': "l", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o", 'œ': "oe",
+	'ř': "r", 'ś': "s", 'š': "s", 'ş': "s", 'ß': "ss", 'ť': "t", 'ţ': "t", 'þ': "th",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ý': "y", 'ÿ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+}
+
+// Transliterate spells accented Latin letters in ASCII, keeping their case: "Ünïcode"
+// becomes "Unicode" and "Straße" becomes "Strasse". Other characters are unchanged.
+func Transliterate(s string) string {
+	var b strings.Builder
+	for _, char := range s {
+		ascii, ok := asciiLetters[unicode.ToLower(char)]
+		switch {
+		case !ok:
+			b.WriteRune(char)
+		case unicode.IsUpper(char):
+			b.WriteString(strings.ToUpper(ascii[:1]) + ascii[1:])
+		default:
+			b.WriteString(ascii)
+		}
+	}
+	return b.String()
+}
+
+// TemplateContext returns the values templates are rendered with. The project, author,
+// python, deps, features and answers maps hold the full configuration; the flat keys such as
+// project_name and python_version are kept as aliases for existing templates.
 func (pc *ProjectConfig) TemplateContext() map[string]interface{} {
 	// Create ruff-compatible Python version (e.g., "3.13" -> "py313")
 	pythonVersionForRuff := "py" + strings.ReplaceAll(pc.PythonVersion, ".", "")
-	
+	projectSlug := SanitizeProjectName(pc.ProjectName)
+	major, minor := pythonVersionParts(pc.PythonVersion)
+
+	kind := pc.ProjectType
+	if framework := pc.Framework(); framework != "" {
+		kind += "/" + framework
+	}
+
+	answers := make(map[string]interface{}, len(pc.Answers))
+	for id, answer := range pc.Answers {
+		answers[id] = answer
+	}
+
 	return map[string]interface{}{
-		"project_name":              pc.ProjectName,
-		"project_type":              pc.ProjectType,
-		"project_description":       pc.ProjectDescription,
-		"user_name":                 pc.UserName,
-		"email":                     pc.Email,
-		"main_dir_name":             pc.MainDirName,
-		"python_version":            pc.PythonVersion,
-		"python_version_for_ruff":   pythonVersionForRuff,
+		"project": map[string]interface{}{
+			"name":        pc.ProjectName,
+			"slug":        projectSlug,
+			"description": pc.ProjectDescription,
+			"type":        pc.ProjectType,
+			"framework":   pc.Framework(),
+			"kind":        kind,
+			"main_dir":    pc.MainDirName,
+		},
+		"author": map[string]interface{}{
+			"name":  pc.UserName,
+			"email": pc.Email,
+		},
+		"python": map[string]interface{}{
+			"version":         pc.PythonVersion,
+			"major":           major,
+			"minor":           minor,
+			"ruff_target":     pythonVersionForRuff,
+			"pyright_version": pc.PythonVersion,
+		},
+		"deps": map[string]interface{}{
+			"runtime": append([]string{}, pc.Dependencies...),
+			"dev":     append([]string{}, DevDependencies...),
+		},
+		"features": map[string]interface{}{
+			"venv":           pc.SetupEnvironment,
+			"jupyter_kernel": pc.RegisterKernel,
+			"dependencies":   len(pc.Dependencies) > 0,
+		},
+		"answers": answers,
+
+		"project_name":            pc.ProjectName,
+		"project_slug":            projectSlug,
+		"project_type":            pc.ProjectType,
+		"project_description":     pc.ProjectDescription,
+		"user_name":               pc.UserName,
+		"email":                   pc.Email,
+		"main_dir_name":           pc.MainDirName,
+		"python_version":          pc.PythonVersion,
+		"python_version_for_ruff": pythonVersionForRuff,
 	}
 }
+
+// pythonVersionParts splits a version such as "3.12" into its major and minor numbers,
+// returning zero for parts that are missing or not numbers
+func pythonVersionParts(version string) (major, minor int) {
+	majorText, minorText, _ := strings.Cut(version, ".")
+	major, _ = strconv.Atoi(majorText)
+	minorText, _, _ = strings.Cut(minorText, ".")
+	minor, _ = strconv.Atoi(minorText)
+	return major, minor
+}
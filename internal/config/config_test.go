@@ -23,6 +23,15 @@ func TestWebFrameworks(t *testing.T) {
 	}
 }
 
+func TestCLIFrameworks(t *testing.T) {
+	frameworks := CLIFrameworks()
+	expected := []string{"typer", "click"}
+
+	if !reflect.DeepEqual(frameworks, expected) {
+		t.Errorf("CLIFrameworks() = %v, want %v", frameworks, expected)
+	}
+}
+
 func TestSanitizeProjectName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -89,6 +98,16 @@ func TestSanitizeProjectName(t *testing.T) {
 			input:    "project🚀test",
 			expected: "projecttest",
 		},
+		{
+			name:     "accented letters transliterated",
+			input:    "Ünïcode App",
+			expected: "unicode-app",
+		},
+		{
+			name:     "letters spelled with two ascii letters",
+			input:    "Straße Œuvre",
+			expected: "strasse-oeuvre",
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,6 +120,53 @@ func TestSanitizeProjectName(t *testing.T) {
 	}
 }
 
+func TestTransliterate(t *testing.T) {
+	tests := map[string]string{
+		"Ünïcode App":  "Unicode App",
+		"Crème Brûlée": "Creme Brulee",
+		"Łódź":         "Lodz",
+		"Straße":       "Strasse",
+		"Æsir":         "Aesir",
+		"日本 app":       "日本 app",
+	}
+
+	for input, want := range tests {
+		if got := Transliterate(input); got != want {
+			t.Errorf("Transliterate(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestDefaultMainDirName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "simple", input: "myproject", expected: "myproject"},
+		{name: "hyphens to underscores", input: "my-project", expected: "my_project"},
+		{name: "spaces to underscores", input: "My Project", expected: "my_project"},
+		{name: "runs of separators collapse", input: "my - project..core", expected: "my_project_core"},
+		{name: "underscores preserved", input: "my_project", expected: "my_project"},
+		{name: "surrounding separators dropped", input: "  -my project!- ", expected: "my_project"},
+		{name: "accented letters transliterated", input: "Ünïcode App", expected: "unicode_app"},
+		{name: "two letter spellings", input: "Straße Œuvre", expected: "strasse_oeuvre"},
+		{name: "leading digits", input: "2048 Game", expected: "_2048_game"},
+		{name: "digits kept inside", input: "web3 api v2", expected: "web3_api_v2"},
+		{name: "untransliterated characters dropped", input: "app 日本", expected: "app"},
+		{name: "nothing usable", input: "日本", expected: ""},
+		{name: "empty", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultMainDirName(tt.input); got != tt.expected {
+				t.Errorf("DefaultMainDirName(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestProjectConfig_TemplateContext(t *testing.T) {
 	config := &ProjectConfig{
 		UserName:           "John Doe",
@@ -118,6 +184,7 @@ func TestProjectConfig_TemplateContext(t *testing.T) {
 
 	expectedKeys := []string{
 		"project_name",
+		"project_slug",
 		"project_type", 
 		"project_description",
 		"user_name",
@@ -151,6 +218,10 @@ func TestProjectConfig_TemplateContext(t *testing.T) {
 		t.Errorf("TemplateContext()[\"python_version\"] = %v, want %v", context["python_version"], config.PythonVersion)
 	}
 
+	if context["project_slug"] != "test-project" {
+		t.Errorf("TemplateContext()[\"project_slug\"] = %v, want %v", context["project_slug"], "test-project")
+	}
+
 	// Check python_version_for_ruff formatting
 	expectedRuffVersion := "py311"
 	if context["python_version_for_ruff"] != expectedRuffVersion {
@@ -238,7 +309,39 @@ func TestProjectConfig_ContextIntegrity(t *testing.T) {
 
 	// Verify context contains exactly what we expect
 	expectedContext := map[string]interface{}{
+		"project": map[string]interface{}{
+			"name":        "integration-test",
+			"slug":        "integration-test",
+			"description": "Integration test project",
+			"type":        "basic",
+			"framework":   "",
+			"kind":        "basic",
+			"main_dir":    "integration_test",
+		},
+		"author": map[string]interface{}{
+			"name":  "Test User",
+			"email": "test@test.com",
+		},
+		"python": map[string]interface{}{
+			"version":         "3.12",
+			"major":           3,
+			"minor":           12,
+			"ruff_target":     "py312",
+			"pyright_version": "3.12",
+		},
+		"deps": map[string]interface{}{
+			"runtime": []string{},
+			"dev":     []string{"ruff", "pyright"},
+		},
+		"features": map[string]interface{}{
+			"venv":           false,
+			"jupyter_kernel": false,
+			"dependencies":   false,
+		},
+		"answers": map[string]interface{}{},
+
 		"project_name":              "integration-test",
+		"project_slug":              "integration-test",
 		"project_type":              "basic", 
 		"project_description":       "Integration test project",
 		"user_name":                 "Test User",
@@ -253,6 +356,64 @@ func TestProjectConfig_ContextIntegrity(t *testing.T) {
 	}
 }
 
+func TestProjectConfig_TemplateContextNested(t *testing.T) {
+	config := &ProjectConfig{
+		UserName:         "Jane Doe",
+		ProjectName:      "My API",
+		ProjectType:      "web",
+		WebFramework:     "fastapi",
+		PythonVersion:    "3.13",
+		Dependencies:     []string{"sqlalchemy", "pydantic-settings"},
+		SetupEnvironment: true,
+	}
+
+	context := config.TemplateContext()
+
+	project := context["project"].(map[string]interface{})
+	if project["kind"] != "web/fastapi" || project["framework"] != "fastapi" || project["slug"] != "my-api" {
+		t.Errorf("TemplateContext()[\"project\"] = %v, want the web/fastapi kind and my-api slug", project)
+	}
+
+	python := context["python"].(map[string]interface{})
+	if python["major"] != 3 || python["minor"] != 13 || python["ruff_target"] != "py313" {
+		t.Errorf("TemplateContext()[\"python\"] = %v, want 3.13 split into major and minor", python)
+	}
+
+	deps := context["deps"].(map[string]interface{})
+	if !reflect.DeepEqual(deps["runtime"], config.Dependencies) {
+		t.Errorf("TemplateContext()[\"deps\"][\"runtime\"] = %v, want %v", deps["runtime"], config.Dependencies)
+	}
+
+	features := context["features"].(map[string]interface{})
+	if features["venv"] != true || features["dependencies"] != true || features["jupyter_kernel"] != false {
+		t.Errorf("TemplateContext()[\"features\"] = %v, want venv and dependencies on", features)
+	}
+
+	// The flat keys stay as aliases of the nested ones
+	if context["project_name"] != project["name"] || context["user_name"] != context["author"].(map[string]interface{})["name"] {
+		t.Errorf("Flat aliases do not match the nested values: %v", context)
+	}
+}
+
+func TestPythonVersionParts(t *testing.T) {
+	tests := []struct {
+		version      string
+		major, minor int
+	}{
+		{"3.12", 3, 12},
+		{"3.13.1", 3, 13},
+		{"3", 3, 0},
+		{"", 0, 0},
+		{"latest", 0, 0},
+	}
+
+	for _, tt := range tests {
+		if major, minor := pythonVersionParts(tt.version); major != tt.major || minor != tt.minor {
+			t.Errorf("pythonVersionParts(%q) = %d, %d, want %d, %d", tt.version, major, minor, tt.major, tt.minor)
+		}
+	}
+}
+
 // Test edge cases and potential security issues
 func TestSanitizeProjectName_EdgeCases(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/manifest"
+)
+
+// ProjectKind is one generatable combination of project type and framework
+type ProjectKind struct {
+	Type        string // Project type, e.g. "web"
+	Framework   string // Framework within the type, empty for types without a framework choice
+	Dir         string // Template directory whose manifest lists the kind's files, e.g. "web/fastapi"
+	Implemented bool   // Unimplemented kinds are hidden from prompts and rejected before generation
+
+	// Kinds contributed by an installed template pack
+	Pack        string             // Name of the pack, empty for built-in kinds
+	Description string             // One-line summary shown when listing packs
+	TemplateDir string             // Pack directory layered over the embedded templates while generating
+	Manifest    *manifest.Manifest // Files the pack lists inline in its pack.yaml, used instead of Dir
+	Questions   []Question         // Extra questions asked after the built-in ones
+}
+
+// Question types a template pack can declare
+const (
+	QuestionInput       = "input"
+	QuestionSelect      = "select"
+	QuestionMultiSelect = "multiselect"
+	QuestionConfirm     = "confirm"
+)
+
+// Question is an extra question a template pack asks for one of its kinds. The answer
+// is available to templates as answers.<id>: a string for input and select questions,
+// a list of strings for multiselect and a boolean for confirm.
+type Question struct {
+	ID       string      `yaml:"id"`
+	Type     string      `yaml:"type"` // One of the Question* types, input when empty
+	Message  string      `yaml:"message"`
+	Help     string      `yaml:"help"`
+	Default  interface{} `yaml:"default"`
+	Required bool        `yaml:"required"`
+	Choices  []string    `yaml:"choices"` // Options of select and multiselect questions, allowed input answers otherwise
+	Pattern  string      `yaml:"pattern"` // Regular expression input answers must match
+	When     string      `yaml:"when"`    // Pongo2 condition over the template context, including earlier answers
+}
+
+// Kind returns the question's type, defaulting to input
+func (q Question) Kind() string {
+	if q.Type == "" {
+		return QuestionInput
+	}
+	return q.Type
+}
+
+// ID identifies the kind, e.g. "basic" or "web/fastapi"
+func (k ProjectKind) ID() string {
+	if k.Framework == "" {
+		return k.Type
+	}
+	return k.Type + "/" + k.Framework
+}
+
+// CommonDir is the template directory whose manifest is rendered for every kind of project
+const CommonDir = "core"
+
+// PartialsDir holds the base templates and partials other templates extend or include.
+// They are never rendered on their own, so no manifest lists them.
+const PartialsDir = CommonDir + "/_partials"
+
+// projectKinds is the registry of every kind pyinit knows about, in prompt order
+var projectKinds = []ProjectKind{
+	{Type: "basic", Dir: "basic", Implemented: true},
+	{Type: "cli", Framework: "typer", Dir: "cli/typer", Implemented: true},
+	{Type: "cli", Framework: "click", Dir: "cli/click", Implemented: true},
+	{Type: "web", Framework: "fastapi", Dir: "web/fastapi", Implemented: true},
+	{Type: "web", Framework: "flask", Dir: "web/flask", Implemented: true},
+	{Type: "web", Framework: "django", Dir: "web/django", Implemented: true},
+	{Type: "library", Dir: "library", Implemented: true},
+	{Type: "data-science", Dir: "data-science", Implemented: true},
+}
+
+// ProjectKinds returns every registered kind, implemented or not
+func ProjectKinds() []ProjectKind {
+	return append([]ProjectKind(nil), projectKinds...)
+}
+
+// RegisterKind adds a kind contributed by a template pack to the registry. Reinstalling
+// the same pack replaces its kinds; clashing with a built-in or another pack's kind fails.
+func RegisterKind(kind ProjectKind) error {
+	if kind.Pack == "" {
+		return fmt.Errorf("kind %s does not belong to a template pack", kind.ID())
+	}
+
+	for i, existing := range projectKinds {
+		if existing.ID() != kind.ID() {
+			continue
+		}
+		if existing.Pack != kind.Pack {
+			return fmt.Errorf("kind %s from pack %s clashes with %s", kind.ID(), kind.Pack, kindOrigin(existing))
+		}
+		projectKinds[i] = kind
+		return nil
+	}
+
+	projectKinds = append(projectKinds, kind)
+	return nil
+}
+
+// UnregisterPack removes every kind contributed by the named pack from the registry
+func UnregisterPack(pack string) {
+	if pack == "" {
+		return
+	}
+
+	var kinds []ProjectKind
+	for _, kind := range projectKinds {
+		if kind.Pack != pack {
+			kinds = append(kinds, kind)
+		}
+	}
+	projectKinds = kinds
+}
+
+// kindOrigin describes where a registered kind comes from
+func kindOrigin(kind ProjectKind) string {
+	if kind.Pack == "" {
+		return "the built-in " + kind.ID() + " kind"
+	}
+	return "the " + kind.ID() + " kind from pack " + kind.Pack
+}
+
+// ProjectTypes returns the project types that have at least one implemented kind
+func ProjectTypes() []string {
+	var types []string
+	seen := map[string]bool{}
+	for _, kind := range projectKinds {
+		if kind.Implemented && !seen[kind.Type] {
+			seen[kind.Type] = true
+			types = append(types, kind.Type)
+		}
+	}
+	return types
+}
+
+// Frameworks returns the implemented frameworks for a project type
+func Frameworks(projectType string) []string {
+	var frameworks []string
+	for _, kind := range projectKinds {
+		if kind.Implemented && kind.Type == projectType && kind.Framework != "" {
+			frameworks = append(frameworks, kind.Framework)
+		}
+	}
+	return frameworks
+}
+
+// WebFrameworks returns available web frameworks
+func WebFrameworks() []string {
+	return Frameworks("web")
+}
+
+// CLIFrameworks returns available command-line frameworks
+func CLIFrameworks() []string {
+	return Frameworks("cli")
+}
+
+// Framework returns the framework chosen for the project's type, if the type has one
+func (pc *ProjectConfig) Framework() string {
+	switch pc.ProjectType {
+	case "web":
+		return pc.WebFramework
+	case "cli":
+		return pc.CLIFramework
+	}
+	return ""
+}
+
+// Kind resolves the registered kind for the configuration, failing for unknown or
+// unimplemented combinations
+func (pc *ProjectConfig) Kind() (ProjectKind, error) {
+	framework := pc.Framework()
+
+	var known []ProjectKind
+	for _, kind := range projectKinds {
+		if kind.Type == pc.ProjectType {
+			known = append(known, kind)
+		}
+	}
+	if len(known) == 0 {
+		return ProjectKind{}, fmt.Errorf("unknown project type %q (available: %s)",
+			pc.ProjectType, strings.Join(ProjectTypes(), ", "))
+	}
+
+	for _, kind := range known {
+		if kind.Framework != framework {
+			continue
+		}
+		if !kind.Implemented {
+			return ProjectKind{}, fmt.Errorf("%s projects are not implemented yet", kind.ID())
+		}
+		return kind, nil
+	}
+
+	if framework == "" {
+		return ProjectKind{}, fmt.Errorf("%s projects require a framework (available: %s)",
+			pc.ProjectType, strings.Join(Frameworks(pc.ProjectType), ", "))
+	}
+	return ProjectKind{}, fmt.Errorf("unknown %s framework %q (available: %s)",
+		pc.ProjectType, framework, strings.Join(Frameworks(pc.ProjectType), ", "))
+}
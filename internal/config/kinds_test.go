@@ -0,0 +1,123 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProjectKindID(t *testing.T) {
+	if id := (ProjectKind{Type: "basic"}).ID(); id != "basic" {
+		t.Errorf("ID() = %q, want %q", id, "basic")
+	}
+	if id := (ProjectKind{Type: "web", Framework: "flask"}).ID(); id != "web/flask" {
+		t.Errorf("ID() = %q, want %q", id, "web/flask")
+	}
+}
+
+func TestProjectConfigKind(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    ProjectConfig
+		wantID    string
+		errorText string
+	}{
+		{name: "basic", config: ProjectConfig{ProjectType: "basic"}, wantID: "basic"},
+		{name: "web framework", config: ProjectConfig{ProjectType: "web", WebFramework: "django"}, wantID: "web/django"},
+		{name: "cli framework", config: ProjectConfig{ProjectType: "cli", CLIFramework: "click"}, wantID: "cli/click"},
+		{name: "framework of another type is ignored", config: ProjectConfig{ProjectType: "library", WebFramework: "flask"}, wantID: "library"},
+		{name: "unknown type", config: ProjectConfig{ProjectType: "game"}, errorText: `unknown project type "game"`},
+		{name: "missing framework", config: ProjectConfig{ProjectType: "cli"}, errorText: "cli projects require a framework"},
+		{name: "unknown framework", config: ProjectConfig{ProjectType: "web", WebFramework: "bottle"}, errorText: `unknown web framework "bottle"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := tt.config.Kind()
+			if tt.errorText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+					t.Fatalf("Kind() error = %v, want error containing %q", err, tt.errorText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Kind() unexpected error: %v", err)
+			}
+			if kind.ID() != tt.wantID {
+				t.Errorf("Kind().ID() = %q, want %q", kind.ID(), tt.wantID)
+			}
+		})
+	}
+}
+
+func TestUnimplementedKindsAreHidden(t *testing.T) {
+	original := projectKinds
+	defer func() { projectKinds = original }()
+
+	projectKinds = append(ProjectKinds(),
+		ProjectKind{Type: "web", Framework: "pyramid"},
+		ProjectKind{Type: "game"},
+	)
+
+	if frameworks := WebFrameworks(); !reflect.DeepEqual(frameworks, []string{"fastapi", "flask", "django"}) {
+		t.Errorf("WebFrameworks() = %v, want unimplemented pyramid hidden", frameworks)
+	}
+	for _, projectType := range ProjectTypes() {
+		if projectType == "game" {
+			t.Error("ProjectTypes() lists the unimplemented game type")
+		}
+	}
+
+	for _, cfg := range []ProjectConfig{
+		{ProjectType: "web", WebFramework: "pyramid"},
+		{ProjectType: "game"},
+	} {
+		if _, err := cfg.Kind(); err == nil || !strings.Contains(err.Error(), "not implemented yet") {
+			t.Errorf("Kind() for %+v error = %v, want not implemented", cfg, err)
+		}
+	}
+}
+
+func TestRegisterKind(t *testing.T) {
+	original := projectKinds
+	defer func() { projectKinds = original }()
+	projectKinds = ProjectKinds()
+	builtinTypes := ProjectTypes()
+
+	service := ProjectKind{Type: "acme-service", Pack: "acme", Implemented: true}
+	if err := RegisterKind(service); err != nil {
+		t.Fatalf("RegisterKind() unexpected error: %v", err)
+	}
+	if types := ProjectTypes(); types[len(types)-1] != "acme-service" {
+		t.Errorf("ProjectTypes() = %v, want acme-service offered last", types)
+	}
+
+	// Re-registering from the same pack replaces the kind instead of duplicating it
+	service.Description = "updated"
+	if err := RegisterKind(service); err != nil {
+		t.Fatalf("RegisterKind() re-register error: %v", err)
+	}
+	kind, err := (&ProjectConfig{ProjectType: "acme-service"}).Kind()
+	if err != nil || kind.Description != "updated" {
+		t.Errorf("Kind() = %+v, %v; want the updated pack kind", kind, err)
+	}
+	if len(projectKinds) != len(original)+1 {
+		t.Errorf("Registry has %d kinds, want %d", len(projectKinds), len(original)+1)
+	}
+
+	clashes := []ProjectKind{
+		{Type: "web", Framework: "fastapi", Pack: "acme", Implemented: true},
+		{Type: "acme-service", Pack: "other", Implemented: true},
+		{Type: "orphan", Implemented: true},
+	}
+	for _, kind := range clashes {
+		if err := RegisterKind(kind); err == nil {
+			t.Errorf("RegisterKind(%s from %q) succeeded, want error", kind.ID(), kind.Pack)
+		}
+	}
+
+	UnregisterPack("acme")
+	if !reflect.DeepEqual(ProjectTypes(), builtinTypes) {
+		t.Errorf("ProjectTypes() after UnregisterPack = %v, want the built-in types", ProjectTypes())
+	}
+}
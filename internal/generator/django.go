@@ -0,0 +1,18 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+)
+
+// djangoConfigPackage is the Django project package holding settings, urls, wsgi and asgi
+const djangoConfigPackage = "config"
+
+// validateDjangoProject rejects a main directory that would shadow the project package
+func validateDjangoProject(cfg *config.ProjectConfig) error {
+	if cfg.MainDirName == djangoConfigPackage {
+		return fmt.Errorf("main directory name %q is reserved for the Django project package", djangoConfigPackage)
+	}
+	return nil
+}
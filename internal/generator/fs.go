@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// fileSystem receives every directory and file the generator produces.
+// Paths are relative to the project root.
+type fileSystem interface {
+	MkdirAll(relPath string) error
+	WriteFile(relPath string, data []byte, perm os.FileMode, templateName string) error
+}
+
+// diskFS writes generated output below a root directory on disk
+type diskFS struct {
+	root string
+}
+
+func (d *diskFS) MkdirAll(relPath string) error {
+	return os.MkdirAll(filepath.Join(d.root, relPath), 0755)
+}
+
+func (d *diskFS) WriteFile(relPath string, data []byte, perm os.FileMode, templateName string) error {
+	path := filepath.Join(d.root, relPath)
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	// Apply the mode exactly, whatever the umask
+	return os.Chmod(path, perm)
+}
@@ -2,43 +2,210 @@ package generator
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
 	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/manifest"
+	"github.com/Pradyothsp/pyinit/internal/prompts"
 	"github.com/Pradyothsp/pyinit/pkg/template"
 )
 
+// kindValidators checks configuration constraints of kinds that a manifest cannot express
+var kindValidators = map[string]func(*config.ProjectConfig) error{
+	"web/django": validateDjangoProject,
+}
+
+// ConfirmFunc decides whether generation may continue into an existing path
+type ConfirmFunc func(path string) (bool, error)
+
 // Generator handles project generation
 type Generator struct {
-	templateEngine *template.Engine
+	templateEngine   *template.Engine
+	templateDirs     []string
+	confirmDirectory ConfirmFunc
+	fs               fileSystem
 }
 
 // New creates a new Generator instance
 func New() *Generator {
 	return &Generator{
-		templateEngine: template.NewEngine(),
+		templateEngine:   template.NewEngine(),
+		confirmDirectory: prompts.ConfirmDirectoryCreation,
+	}
+}
+
+// SetTemplateDir layers a user template directory over the embedded templates
+func (g *Generator) SetTemplateDir(dir string) error {
+	if err := g.templateEngine.SetTemplateDir(dir); err != nil {
+		return err
 	}
+	g.templateDirs = append(g.templateDirs, dir)
+	return nil
+}
+
+// EngineFor returns the template engine for kind. Pack kinds get the pack directory
+// layered between the embedded templates and the user's template directories.
+func (g *Generator) EngineFor(kind config.ProjectKind) (*template.Engine, error) {
+	if kind.TemplateDir == "" {
+		return g.templateEngine, nil
+	}
+
+	engine := template.NewEngine()
+	for _, dir := range append([]string{kind.TemplateDir}, g.templateDirs...) {
+		if err := engine.SetTemplateDir(dir); err != nil {
+			return nil, fmt.Errorf("failed to load templates for %s: %w", kind.ID(), err)
+		}
+	}
+	return engine, nil
+}
+
+// SetDirectoryConfirmation replaces the interactive "directory exists" prompt
+func (g *Generator) SetDirectoryConfirmation(fn ConfirmFunc) {
+	g.confirmDirectory = fn
 }
 
 // GenerateProject creates the complete project structure
 func (g *Generator) GenerateProject(cfg *config.ProjectConfig) error {
-	// Common steps
-	if err := g.GenerateCommonProject(cfg); err != nil {
-		return fmt.Errorf("failed to create project: %w", err)
+	// Refuse unsupported kinds before anything touches disk
+	bp, err := g.prepare(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Check whether we may write into the project directory
+	if err := g.confirmProjectDirectory(cfg); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+
+	// Stage everything next to the target so a failure leaves no partial project behind
+	tx, err := beginTransaction(cfg.ProjectPath)
+	if err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+
+	g.fs = &diskFS{root: tx.staging}
+	if err := g.generate(cfg, bp); err != nil {
+		tx.rollback()
+		return err
+	}
+
+	return tx.commit()
+}
+
+// PlanProject renders the complete project into memory without touching disk
+func (g *Generator) PlanProject(cfg *config.ProjectConfig) (*Plan, error) {
+	bp, err := g.prepare(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := NewPlan(cfg.ProjectPath)
+
+	g.fs = plan
+	if err := g.generate(cfg, bp); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// Manifests returns the manifests listing the files of cfg's project kind: the common
+// one first, then the kind's own
+func (g *Generator) Manifests(cfg *config.ProjectConfig) ([]*manifest.Manifest, error) {
+	bp, err := g.prepare(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return bp.manifests, nil
+}
+
+// blueprint is everything needed to generate a project: its kind, the engine that
+// renders its templates and the manifests listing its files
+type blueprint struct {
+	kind      config.ProjectKind
+	engine    *template.Engine
+	manifests []*manifest.Manifest
+}
+
+// prepare verifies that cfg names an implemented kind, loads the manifests of the
+// common and kind-specific template directories and checks that every template they
+// list can be loaded
+func (g *Generator) prepare(cfg *config.ProjectConfig) (*blueprint, error) {
+	kind, err := cfg.Kind()
+	if err != nil {
+		return nil, err
+	}
+
+	if validate, ok := kindValidators[kind.ID()]; ok {
+		if err := validate(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	engine, err := g.EngineFor(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	common, err := loadManifest(engine, config.CommonDir)
+	if err != nil {
+		return nil, err
 	}
 
-	if cfg.ProjectType == "basic" {
-		if err := g.GeneratorBasicProject(cfg); err != nil {
-			return fmt.Errorf("failed to create basic project structure %w", err)
+	// Pack kinds list their files inline; built-in kinds carry a manifest in their template directory
+	kindManifest := kind.Manifest
+	if kindManifest == nil {
+		if kindManifest, err = loadManifest(engine, kind.Dir); err != nil {
+			return nil, fmt.Errorf("%s projects have no manifest: %w", kind.ID(), err)
 		}
 	}
 
-	if cfg.ProjectType == "web" {
-		if cfg.WebFramework == "fastapi" {
-			if err := g.GenerateFastAPIProject(cfg); err != nil {
-				return fmt.Errorf("failed to create fastapi project %w", err)
+	bp := &blueprint{kind: kind, engine: engine, manifests: []*manifest.Manifest{common, kindManifest}}
+
+	var missing []string
+	for _, m := range bp.manifests {
+		for _, name := range m.Templates() {
+			if !engine.HasTemplate(name) {
+				missing = append(missing, name)
 			}
 		}
 	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s projects are missing templates: %s", kind.ID(), strings.Join(missing, ", "))
+	}
+
+	return bp, nil
+}
+
+// loadManifest reads the manifest of a template directory through engine, so template
+// directories layered over the embedded ones can replace it
+func loadManifest(engine *template.Engine, dir string) (*manifest.Manifest, error) {
+	name := path.Join(dir, manifest.FileName)
+
+	data, err := engine.ReadTemplate(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	m, err := manifest.Parse(data, dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return m, nil
+}
+
+// generate renders every manifest of the blueprint into g.fs
+func (g *Generator) generate(cfg *config.ProjectConfig, bp *blueprint) error {
+	base := g.templateEngine
+	g.templateEngine = bp.engine
+	defer func() { g.templateEngine = base }()
+
+	for _, m := range bp.manifests {
+		if err := g.renderManifest(cfg, m); err != nil {
+			return fmt.Errorf("failed to create %s project: %w", bp.kind.ID(), err)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,342 @@
+package generator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Pradyothsp/pyinit"
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/pkg/template"
+)
+
+// configForKind returns a test configuration selecting kind
+func configForKind(kind config.ProjectKind, projectPath string) *config.ProjectConfig {
+	cfg := createBasicTestConfig(projectPath)
+	cfg.ProjectType = kind.Type
+	switch kind.Type {
+	case "web":
+		cfg.WebFramework = kind.Framework
+	case "cli":
+		cfg.CLIFramework = kind.Framework
+	}
+	return cfg
+}
+
+func TestEveryKindHasAManifest(t *testing.T) {
+	engine := template.NewEngine()
+
+	if _, err := loadManifest(engine, config.CommonDir); err != nil {
+		t.Errorf("Common manifest: %v", err)
+	}
+	for _, kind := range config.ProjectKinds() {
+		if !kind.Implemented {
+			continue
+		}
+		if _, err := loadManifest(engine, kind.Dir); err != nil {
+			t.Errorf("%s: %v", kind.ID(), err)
+		}
+	}
+}
+
+func TestEmbeddedTemplatesAreAllListed(t *testing.T) {
+	engine := template.NewEngine()
+
+	listed := map[string]bool{}
+	for _, dir := range append(kindDirs(), config.CommonDir) {
+		m, err := loadManifest(engine, dir)
+		if err != nil {
+			t.Fatalf("Failed to load %s manifest: %v", dir, err)
+		}
+		for _, name := range m.Templates() {
+			listed[name] = true
+		}
+	}
+
+	err := fs.WalkDir(pyinit.EmbeddedTemplates, "templates", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(p, ".j2") {
+			return err
+		}
+		name := strings.TrimPrefix(p, "templates/")
+		if strings.HasPrefix(name, config.PartialsDir+"/") {
+			return nil
+		}
+		if !listed[name] {
+			t.Errorf("Template %s is not listed in any manifest", name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk embedded templates: %v", err)
+	}
+}
+
+// kindDirs returns the template directories of the implemented built-in kinds
+func kindDirs() []string {
+	var dirs []string
+	for _, kind := range config.ProjectKinds() {
+		if kind.Implemented && kind.Dir != "" {
+			dirs = append(dirs, kind.Dir)
+		}
+	}
+	return dirs
+}
+
+func TestPlanProject_EveryKindRendersItsDeclaredTemplates(t *testing.T) {
+	for _, kind := range config.ProjectKinds() {
+		if !kind.Implemented {
+			continue
+		}
+
+		t.Run(kind.ID(), func(t *testing.T) {
+			gen := New()
+			cfg := configForKind(kind, filepath.Join(os.TempDir(), "planned"))
+
+			bp, err := gen.prepare(cfg)
+			if err != nil {
+				t.Fatalf("prepare failed: %v", err)
+			}
+			plan, err := gen.PlanProject(cfg)
+			if err != nil {
+				t.Fatalf("PlanProject failed: %v", err)
+			}
+
+			declared := map[string]bool{}
+			for _, m := range bp.manifests {
+				for _, name := range m.Templates() {
+					declared[name] = true
+				}
+			}
+
+			rendered := map[string]bool{}
+			for _, file := range plan.Files() {
+				if file.Template == "" {
+					continue
+				}
+				rendered[file.Template] = true
+				if !declared[file.Template] {
+					t.Errorf("%s is rendered from undeclared template %s", file.Path, file.Template)
+				}
+			}
+
+			for name := range declared {
+				if !rendered[name] {
+					t.Errorf("Declared template %s was never rendered", name)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanProject_LibraryPackagesOnlyTheLibrary(t *testing.T) {
+	cfg := configForKind(config.ProjectKind{Type: "library"}, filepath.Join(os.TempDir(), "planned"))
+	plan, err := New().PlanProject(cfg)
+	if err != nil {
+		t.Fatalf("PlanProject failed: %v", err)
+	}
+
+	var pyproject struct {
+		Project struct {
+			Scripts map[string]string `toml:"scripts"`
+		} `toml:"project"`
+		Tool struct {
+			Setuptools struct {
+				PackageDir map[string]string `toml:"package-dir"`
+				Packages   []string          `toml:"packages"`
+			} `toml:"setuptools"`
+		} `toml:"tool"`
+	}
+	for _, file := range plan.Files() {
+		if filepath.ToSlash(file.Path) == "pyproject.toml" {
+			if _, err := toml.Decode(string(file.Content), &pyproject); err != nil {
+				t.Fatalf("Rendered pyproject.toml is invalid: %v", err)
+			}
+		}
+	}
+
+	setuptools := pyproject.Tool.Setuptools
+	if want := []string{cfg.MainDirName}; !reflect.DeepEqual(setuptools.Packages, want) {
+		t.Errorf("packages = %v, want %v", setuptools.Packages, want)
+	}
+	if want := map[string]string{"": "src"}; !reflect.DeepEqual(setuptools.PackageDir, want) {
+		t.Errorf("package-dir = %v, want %v", setuptools.PackageDir, want)
+	}
+	// The entry points of the dev scripts would point into a package the wheel does not have
+	if len(pyproject.Project.Scripts) > 0 {
+		t.Errorf("project.scripts = %v, want none", pyproject.Project.Scripts)
+	}
+}
+
+func TestGenerateProject_RejectsUnsupportedKinds(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(cfg *config.ProjectConfig)
+		errorText string
+	}{
+		{
+			name:      "unknown type",
+			mutate:    func(cfg *config.ProjectConfig) { cfg.ProjectType = "spaceship" },
+			errorText: `unknown project type "spaceship"`,
+		},
+		{
+			name:      "web without framework",
+			mutate:    func(cfg *config.ProjectConfig) { cfg.ProjectType = "web" },
+			errorText: "web projects require a framework",
+		},
+		{
+			name: "unknown web framework",
+			mutate: func(cfg *config.ProjectConfig) {
+				cfg.ProjectType = "web"
+				cfg.WebFramework = "pyramid"
+			},
+			errorText: `unknown web framework "pyramid"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := createTempTestDir(t)
+			defer cleanupTestDir(t, tempDir)
+
+			cfg := createBasicTestConfig(filepath.Join(tempDir, "project"))
+			tt.mutate(cfg)
+
+			gen := New()
+			gen.SetDirectoryConfirmation(func(string) (bool, error) {
+				t.Error("Directory confirmation must not run for an unsupported kind")
+				return true, nil
+			})
+
+			err := gen.GenerateProject(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+				t.Fatalf("GenerateProject() error = %v, want error containing %q", err, tt.errorText)
+			}
+
+			if _, err := os.Stat(cfg.ProjectPath); !os.IsNotExist(err) {
+				t.Errorf("Project directory was created for an unsupported kind")
+			}
+			if found := leftovers(t, tempDir); len(found) > 0 {
+				t.Errorf("Staging directories left behind: %v", found)
+			}
+		})
+	}
+}
+
+func TestPlanProject_UserTemplateDirCanReplaceManifest(t *testing.T) {
+	templateDir := t.TempDir()
+	files := map[string]string{
+		"basic/manifest.yaml":      "files:\n  - template: README.md.j2\n    output: README.md\n  - template: CONTRIBUTING.md.j2\n    output: CONTRIBUTING.md\n",
+		"basic/CONTRIBUTING.md.j2": "Contributing to {{ project_name }}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(templateDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	gen := New()
+	if err := gen.SetTemplateDir(templateDir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	plan, err := gen.PlanProject(createBasicTestConfig(filepath.Join(os.TempDir(), "planned")))
+	if err != nil {
+		t.Fatalf("PlanProject failed: %v", err)
+	}
+
+	paths := map[string]bool{}
+	for _, file := range plan.Files() {
+		paths[filepath.ToSlash(file.Path)] = true
+	}
+
+	for _, want := range []string{"README.md", "CONTRIBUTING.md", ".gitignore"} {
+		if !paths[want] {
+			t.Errorf("Expected %s in the plan", want)
+		}
+	}
+	// Files dropped from the replaced manifest are not generated
+	for _, dropped := range []string{"pyproject.toml", "test_project/main.py"} {
+		if paths[dropped] {
+			t.Errorf("Did not expect %s in the plan", dropped)
+		}
+	}
+}
+
+func TestPlanProject_UserTemplateDirCanOverridePartials(t *testing.T) {
+	templateDir := t.TempDir()
+	partial := filepath.Join(templateDir, filepath.FromSlash(config.PartialsDir), "readme", "development-commands.sh.j2")
+	if err := os.MkdirAll(filepath.Dir(partial), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(partial, []byte("make check\n"), 0644); err != nil {
+		t.Fatalf("Failed to write partial: %v", err)
+	}
+
+	gen := New()
+	if err := gen.SetTemplateDir(templateDir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	// Every kind whose README extends the base README picks up the replaced partial
+	for _, kind := range []config.ProjectKind{
+		{Type: "cli", Framework: "typer"},
+		{Type: "web", Framework: "django"},
+	} {
+		plan, err := gen.PlanProject(configForKind(kind, filepath.Join(os.TempDir(), "planned")))
+		if err != nil {
+			t.Fatalf("PlanProject(%s) failed: %v", kind.ID(), err)
+		}
+
+		var readme string
+		for _, file := range plan.Files() {
+			if file.Path == "README.md" {
+				readme = string(file.Content)
+			}
+		}
+		if !strings.Contains(readme, "```bash\nmake check\n") || strings.Contains(readme, "uv run fmt-check") {
+			t.Errorf("%s README does not use the replaced partial:\n%s", kind.ID(), readme)
+		}
+	}
+}
+
+func TestPlanProject_TemplateErrorNamesFiles(t *testing.T) {
+	templateDir := t.TempDir()
+	partial := filepath.Join(templateDir, filepath.FromSlash(config.PartialsDir), "readme", "development-commands.sh.j2")
+	if err := os.MkdirAll(filepath.Dir(partial), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(partial, []byte("uv sync\n{{ main_dir_name|missing_filter }}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write partial: %v", err)
+	}
+
+	gen := New()
+	if err := gen.SetTemplateDir(templateDir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	projectPath := filepath.Join(os.TempDir(), "planned")
+	_, err := gen.PlanProject(configForKind(config.ProjectKind{Type: "cli", Framework: "typer"}, projectPath))
+
+	var templateErr *template.TemplateError
+	if !errors.As(err, &templateErr) {
+		t.Fatalf("PlanProject() error = %v, want a TemplateError", err)
+	}
+	if templateErr.Template != "cli/typer/README.md.j2" || templateErr.Source != partial || templateErr.Line != 2 {
+		t.Errorf("TemplateError = %+v, want the README failing at line 2 of the partial", templateErr)
+	}
+	if templateErr.Output != filepath.Join(projectPath, "README.md") {
+		t.Errorf("TemplateError.Output = %q, want the README in the project", templateErr.Output)
+	}
+	if !strings.Contains(err.Error(), partial+":2:") {
+		t.Errorf("PlanProject() error = %v, want the partial's path and line", err)
+	}
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/manifest"
+)
+
+// renderManifest creates the packages and files a manifest lists
+func (g *Generator) renderManifest(cfg *config.ProjectConfig, m *manifest.Manifest) error {
+	context := cfg.TemplateContext()
+
+	// Packages are directories with an empty __init__.py
+	for _, pkg := range m.Packages {
+		dir, err := g.outputPath(pkg, context)
+		if err != nil {
+			return err
+		}
+		if err := g.fs.MkdirAll(dir); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+		if err := g.fs.WriteFile(filepath.Join(dir, "__init__.py"), []byte(""), manifest.DefaultMode, ""); err != nil {
+			return fmt.Errorf("failed to create __init__.py in %s: %w", dir, err)
+		}
+	}
+
+	for _, file := range m.Files {
+		if file.When != "" {
+			include, err := g.evaluateCondition(file.When, context)
+			if err != nil {
+				return err
+			}
+			if !include {
+				continue
+			}
+		}
+
+		relPath, err := g.outputPath(file.Output, context)
+		if err != nil {
+			return err
+		}
+		perm, err := file.Perm()
+		if err != nil {
+			return fmt.Errorf("%s: %w", relPath, err)
+		}
+
+		if dir := filepath.Dir(relPath); dir != "." {
+			if err := g.fs.MkdirAll(dir); err != nil {
+				return fmt.Errorf("failed to create %s: %w", dir, err)
+			}
+		}
+
+		if file.Template == "" {
+			if err := g.fs.WriteFile(relPath, []byte(""), perm, ""); err != nil {
+				return fmt.Errorf("failed to create %s: %w", relPath, err)
+			}
+			continue
+		}
+
+		if err := g.generateFileFromTemplate(cfg, file.Template, relPath, perm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// outputPath renders a templated manifest path and checks it stays inside the project
+func (g *Generator) outputPath(output string, context map[string]interface{}) (string, error) {
+	rendered, err := g.templateEngine.RenderString(output, context)
+	if err != nil {
+		return "", fmt.Errorf("failed to render output path: %w", err)
+	}
+	if !manifest.IsRelativePath(rendered) {
+		return "", fmt.Errorf("output path %q must stay inside the project", rendered)
+	}
+
+	return filepath.FromSlash(path.Clean(rendered)), nil
+}
+
+// evaluateCondition reports whether a manifest `when` expression is true for context
+func (g *Generator) evaluateCondition(condition string, context map[string]interface{}) (bool, error) {
+	result, err := g.templateEngine.RenderString("{% if "+condition+" %}true{% endif %}", context)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition %q: %w", condition, err)
+	}
+
+	return strings.TrimSpace(result) == "true", nil
+}
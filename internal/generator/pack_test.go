@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/manifest"
+)
+
+// registerTestPack writes templates into a pack directory and registers a kind using them
+func registerTestPack(t *testing.T, templates map[string]string, files []manifest.File) config.ProjectKind {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range templates {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	kind := config.ProjectKind{
+		Type:        "acme-service",
+		Implemented: true,
+		Pack:        "acme",
+		TemplateDir: dir,
+		Manifest:    &manifest.Manifest{Files: files},
+	}
+	if err := config.RegisterKind(kind); err != nil {
+		t.Fatalf("RegisterKind failed: %v", err)
+	}
+	t.Cleanup(func() { config.UnregisterPack("acme") })
+
+	return kind
+}
+
+func TestPlanProject_PackKind(t *testing.T) {
+	kind := registerTestPack(t, map[string]string{
+		"service/README.md.j2":      "# {{ project_name }} service\n",
+		"service/app.py.j2":         "APP = \"{{ main_dir_name }}\"\n",
+		"core/python-version.j2":    "{{ python_version }}-acme\n",
+		"service/unused-partial.j2": "never rendered on its own",
+	}, []manifest.File{
+		{Template: "service/README.md.j2", Output: "README.md"},
+		{Output: "{{ main_dir_name }}/__init__.py"},
+		{Template: "service/app.py.j2", Output: "{{ main_dir_name }}/app.py"},
+	})
+
+	plan, err := New().PlanProject(configForKind(kind, filepath.Join(os.TempDir(), "planned")))
+	if err != nil {
+		t.Fatalf("PlanProject failed: %v", err)
+	}
+
+	contents := map[string]string{}
+	for _, file := range plan.Files() {
+		contents[filepath.ToSlash(file.Path)] = string(file.Content)
+	}
+
+	want := map[string]string{
+		"README.md":                "# test-project service\n",
+		"test_project/__init__.py": "",
+		"test_project/app.py":      "APP = \"test_project\"\n",
+		// Packs can override the common templates too
+		".python-version": "3.11-acme\n",
+	}
+	for path, content := range want {
+		got, ok := contents[path]
+		if !ok {
+			t.Errorf("Expected %s in the plan", path)
+			continue
+		}
+		if got != content {
+			t.Errorf("%s = %q, want %q", path, got, content)
+		}
+	}
+
+	// Common files the pack does not override still come from the embedded templates
+	if _, ok := contents[".gitignore"]; !ok {
+		t.Error("Expected .gitignore from the embedded templates")
+	}
+}
+
+func TestPlanProject_ManifestConditionsAndModes(t *testing.T) {
+	kind := registerTestPack(t, map[string]string{
+		"service/run.sh.j2": "#!/bin/sh\nexec {{ main_dir_name }}\n",
+	}, []manifest.File{
+		{Template: "service/run.sh.j2", Output: "bin/run.sh", Mode: "0755"},
+		{Output: "only-for-acme.txt", When: `project.type == "acme-service" and project.framework == ""`},
+		{Output: "only-for-flask.txt", When: `project.framework == "flask"`},
+	})
+
+	plan, err := New().PlanProject(configForKind(kind, filepath.Join(os.TempDir(), "planned")))
+	if err != nil {
+		t.Fatalf("PlanProject failed: %v", err)
+	}
+
+	files := map[string]*PlannedFile{}
+	for _, file := range plan.Files() {
+		files[filepath.ToSlash(file.Path)] = file
+	}
+
+	if run, ok := files["bin/run.sh"]; !ok || run.Mode != 0755 {
+		t.Errorf("bin/run.sh = %+v, want it planned with mode 0755", run)
+	}
+	if gitignore, ok := files[".gitignore"]; !ok || gitignore.Mode != 0644 {
+		t.Errorf(".gitignore = %+v, want it planned with mode 0644", gitignore)
+	}
+	if _, ok := files["only-for-acme.txt"]; !ok {
+		t.Error("Expected the file whose condition holds to be planned")
+	}
+	if _, ok := files["only-for-flask.txt"]; ok {
+		t.Error("Expected the file whose condition fails to be skipped")
+	}
+}
+
+func TestPlanProject_PackKindRejectsOutputsOutsideProject(t *testing.T) {
+	kind := registerTestPack(t, map[string]string{
+		"service/README.md.j2": "readme",
+	}, []manifest.File{
+		{Template: "service/README.md.j2", Output: "{{ main_dir_name }}/../../escape.md"},
+	})
+
+	_, err := New().PlanProject(configForKind(kind, filepath.Join(os.TempDir(), "planned")))
+	if err == nil || !strings.Contains(err.Error(), "must stay inside the project") {
+		t.Fatalf("PlanProject() error = %v, want output path error", err)
+	}
+}
+
+func TestGenerateProject_PackKindMissingTemplate(t *testing.T) {
+	kind := registerTestPack(t, map[string]string{}, []manifest.File{
+		{Template: "service/README.md.j2", Output: "README.md"},
+	})
+
+	tempDir := createTempTestDir(t)
+	defer cleanupTestDir(t, tempDir)
+
+	cfg := configForKind(kind, filepath.Join(tempDir, "project"))
+	err := New().GenerateProject(cfg)
+	if err == nil || !strings.Contains(err.Error(), "missing templates: service/README.md.j2") {
+		t.Fatalf("GenerateProject() error = %v, want missing template error", err)
+	}
+	if _, err := os.Stat(cfg.ProjectPath); !os.IsNotExist(err) {
+		t.Error("Project directory was created for a pack with missing templates")
+	}
+}
@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PlannedFile is a file the generator would write
+type PlannedFile struct {
+	Path     string      // Path relative to the project root
+	Template string      // Template the content was rendered from, empty for static files
+	Mode     os.FileMode // Permissions the file is written with
+	Content  []byte
+}
+
+// Plan records generator output in memory instead of writing it to disk
+type Plan struct {
+	Root  string
+	dirs  map[string]bool
+	files map[string]*PlannedFile
+}
+
+// NewPlan creates an empty plan for a project rooted at root
+func NewPlan(root string) *Plan {
+	return &Plan{
+		Root:  root,
+		dirs:  map[string]bool{".": true},
+		files: map[string]*PlannedFile{},
+	}
+}
+
+func (p *Plan) MkdirAll(relPath string) error {
+	for dir := filepath.Clean(relPath); dir != "." && !p.dirs[dir]; dir = filepath.Dir(dir) {
+		if _, isFile := p.files[dir]; isFile {
+			return fmt.Errorf("mkdir %s: a file with that name is planned", dir)
+		}
+		p.dirs[dir] = true
+	}
+	return nil
+}
+
+func (p *Plan) WriteFile(relPath string, data []byte, perm os.FileMode, templateName string) error {
+	relPath = filepath.Clean(relPath)
+	if !p.dirs[filepath.Dir(relPath)] {
+		return fmt.Errorf("open %s: parent directory is not created", relPath)
+	}
+	if p.dirs[relPath] {
+		return fmt.Errorf("open %s: is a directory", relPath)
+	}
+
+	p.files[relPath] = &PlannedFile{Path: relPath, Template: templateName, Mode: perm, Content: data}
+	return nil
+}
+
+// Files returns the planned files sorted by path
+func (p *Plan) Files() []*PlannedFile {
+	files := make([]*PlannedFile, 0, len(p.files))
+	for _, file := range p.files {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// Print writes a tree of the planned project, optionally followed by every rendered file
+func (p *Plan) Print(w io.Writer, showContent bool) {
+	fmt.Fprintf(w, "%s/\n", filepath.Base(p.Root))
+	p.printDir(w, ".", "")
+
+	if !showContent {
+		return
+	}
+
+	for _, file := range p.Files() {
+		fmt.Fprintf(w, "\n──── %s ────\n", filepath.ToSlash(file.Path))
+		fmt.Fprint(w, string(file.Content))
+		if len(file.Content) > 0 && !strings.HasSuffix(string(file.Content), "\n") {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// printDir prints the children of dir with tree connectors
+func (p *Plan) printDir(w io.Writer, dir, indent string) {
+	children := p.children(dir)
+	for i, child := range children {
+		connector, childIndent := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, childIndent = "└── ", "    "
+		}
+
+		name := filepath.Base(child)
+		if p.dirs[child] {
+			fmt.Fprintf(w, "%s%s%s/\n", indent, connector, name)
+			p.printDir(w, child, indent+childIndent)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s%s%s  %s\n", indent, connector, name, describeFile(p.files[child]))
+	}
+}
+
+// children returns the direct children of dir, directories first
+func (p *Plan) children(dir string) []string {
+	var dirs, files []string
+	for path := range p.dirs {
+		if path != "." && filepath.Dir(path) == dir {
+			dirs = append(dirs, path)
+		}
+	}
+	for path := range p.files {
+		if filepath.Dir(path) == dir {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(dirs)
+	sort.Strings(files)
+	return append(dirs, files...)
+}
+
+// describeFile summarises where a planned file came from and how big it is
+func describeFile(file *PlannedFile) string {
+	var parts []string
+	switch {
+	case file.Template != "":
+		parts = append(parts, file.Template, fmt.Sprintf("%d bytes", len(file.Content)))
+	case len(file.Content) == 0:
+		parts = append(parts, "empty")
+	default:
+		parts = append(parts, fmt.Sprintf("%d bytes", len(file.Content)))
+	}
+
+	// Only call out permissions that differ from the usual 0644
+	if file.Mode != 0 && file.Mode != 0644 {
+		parts = append(parts, fmt.Sprintf("mode %04o", file.Mode))
+	}
+
+	return "(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanProject_DoesNotTouchDisk(t *testing.T) {
+	tempDir := createTempTestDir(t)
+	defer cleanupTestDir(t, tempDir)
+
+	cfg := createBasicTestConfig(filepath.Join(tempDir, "planned"))
+
+	plan, err := New().PlanProject(cfg)
+	if err != nil {
+		t.Fatalf("PlanProject failed: %v", err)
+	}
+
+	if _, err := os.Stat(cfg.ProjectPath); !os.IsNotExist(err) {
+		t.Errorf("PlanProject created %s on disk", cfg.ProjectPath)
+	}
+
+	planned := map[string]*PlannedFile{}
+	for _, file := range plan.Files() {
+		planned[filepath.ToSlash(file.Path)] = file
+	}
+
+	for _, path := range []string{".gitignore", "README.md", "pyproject.toml", "scripts/fmt.py", "test_project/main.py"} {
+		if _, ok := planned[path]; !ok {
+			t.Errorf("Expected %s in plan", path)
+		}
+	}
+
+	if readme := planned["README.md"]; readme != nil && readme.Template != "basic/README.md.j2" {
+		t.Errorf("README.md template = %q, want %q", readme.Template, "basic/README.md.j2")
+	}
+}
+
+func TestPlan_Print(t *testing.T) {
+	plan := NewPlan("/tmp/demo")
+	if err := plan.MkdirAll("pkg/sub"); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := plan.WriteFile("pkg/__init__.py", nil, 0644, ""); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := plan.WriteFile("README.md", []byte("# demo\n"), 0644, "basic/README.md.j2"); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	plan.Print(&buf, false)
+	output := buf.String()
+
+	expected := []string{
+		"demo/",
+		"├── pkg/",
+		"│   ├── sub/",
+		"│   └── __init__.py  (empty)",
+		"└── README.md  (basic/README.md.j2, 7 bytes)",
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected output to contain %q\nOutput:\n%s", line, output)
+		}
+	}
+	if strings.Contains(output, "# demo") {
+		t.Error("Content should only be printed with showContent")
+	}
+
+	buf.Reset()
+	plan.Print(&buf, true)
+	if !strings.Contains(buf.String(), "# demo") {
+		t.Error("Expected rendered content with showContent")
+	}
+}
+
+func TestPlan_WriteFileRequiresParent(t *testing.T) {
+	plan := NewPlan("/tmp/demo")
+	if err := plan.WriteFile("missing/file.py", nil, 0644, ""); err == nil {
+		t.Error("Expected error writing into a directory that was never created")
+	}
+}
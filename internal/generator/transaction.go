@@ -0,0 +1,216 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// transaction stages generated output next to the target directory and moves it into place
+// only once generation has fully succeeded
+type transaction struct {
+	target  string
+	staging string
+	existed bool
+	carried []string // Directories of the existing target moved into place on commit instead of copied
+}
+
+// carriedDirs are top-level directories of an existing target that are not copied into
+// the staging directory. They can be large, such as a repository's history or a virtual
+// environment, and generation never writes to them, so commit moves them over instead.
+var carriedDirs = []string{".git", ".venv", "venv", "node_modules"}
+
+// beginTransaction creates a staging directory beside target, seeded with target's current
+// contents. Everything but carriedDirs is copied, so overwriting a large existing directory
+// takes time and, until commit, the disk space of a second copy.
+func beginTransaction(target string) (*transaction, error) {
+	parent := filepath.Dir(target)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create parent directory: %w", err)
+	}
+
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(target)+".pyinit-staging-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	tx := &transaction{target: target, staging: staging}
+
+	info, err := os.Stat(target)
+	switch {
+	case err == nil && !info.IsDir():
+		tx.rollback()
+		return nil, fmt.Errorf("%s exists and is not a directory", target)
+	case err == nil:
+		tx.existed = true
+		for _, name := range carriedDirs {
+			if info, err := os.Lstat(filepath.Join(target, name)); err == nil && info.IsDir() {
+				tx.carried = append(tx.carried, name)
+			}
+		}
+		if err := copyTree(target, staging, tx.carried); err != nil {
+			tx.rollback()
+			return nil, fmt.Errorf("failed to stage existing contents of %s: %w", target, err)
+		}
+		if err := os.Chmod(staging, info.Mode().Perm()); err != nil {
+			tx.rollback()
+			return nil, fmt.Errorf("failed to set staging directory permissions: %w", err)
+		}
+	case os.IsNotExist(err):
+		if err := os.Chmod(staging, 0755); err != nil {
+			tx.rollback()
+			return nil, fmt.Errorf("failed to set staging directory permissions: %w", err)
+		}
+	default:
+		tx.rollback()
+		return nil, fmt.Errorf("failed to inspect %s: %w", target, err)
+	}
+
+	return tx, nil
+}
+
+// commit swaps the staged directory into place. An existing target is kept as a
+// backup until the swap succeeds and is restored if it does not.
+func (tx *transaction) commit() error {
+	if !tx.existed {
+		if err := os.Rename(tx.staging, tx.target); err != nil {
+			tx.rollback()
+			return fmt.Errorf("failed to move project into place: %w", err)
+		}
+		return nil
+	}
+
+	backup, err := os.MkdirTemp(filepath.Dir(tx.target), "."+filepath.Base(tx.target)+".pyinit-backup-*")
+	if err != nil {
+		tx.rollback()
+		return fmt.Errorf("failed to reserve backup directory: %w", err)
+	}
+	if err := os.Remove(backup); err != nil {
+		tx.rollback()
+		return fmt.Errorf("failed to reserve backup directory: %w", err)
+	}
+
+	if err := os.Rename(tx.target, backup); err != nil {
+		tx.rollback()
+		return fmt.Errorf("failed to back up existing directory: %w", err)
+	}
+
+	moved, err := moveEntries(tx.carried, backup, tx.staging)
+	if err == nil {
+		err = os.Rename(tx.staging, tx.target)
+	}
+	if err != nil {
+		// Carried directories go back first, or rollback would delete them with the staging directory
+		if _, moveErr := moveEntries(moved, tx.staging, backup); moveErr != nil {
+			return fmt.Errorf("failed to move project into place: %w (original contents left at %s and %s: %v)", err, backup, tx.staging, moveErr)
+		}
+		tx.rollback()
+		if restoreErr := os.Rename(backup, tx.target); restoreErr != nil {
+			return fmt.Errorf("failed to move project into place: %w (original contents left at %s: %v)", err, backup, restoreErr)
+		}
+		return fmt.Errorf("failed to move project into place: %w", err)
+	}
+
+	if err := os.RemoveAll(backup); err != nil {
+		fmt.Printf("Warning: Failed to remove backup directory %s: %v\n", backup, err)
+	}
+
+	return nil
+}
+
+// rollback discards everything staged so far, leaving the target untouched
+func (tx *transaction) rollback() {
+	if err := os.RemoveAll(tx.staging); err != nil {
+		fmt.Printf("Warning: Failed to remove staging directory %s: %v\n", tx.staging, err)
+	}
+}
+
+// moveEntries renames the named entries of from into to and returns the ones it moved.
+// An entry that is already in to is not replaced.
+func moveEntries(names []string, from, to string) ([]string, error) {
+	var moved []string
+	for _, name := range names {
+		if _, err := os.Lstat(filepath.Join(to, name)); err == nil {
+			return moved, fmt.Errorf("%s already exists in %s", name, to)
+		}
+		if err := os.Rename(filepath.Join(from, name), filepath.Join(to, name)); err != nil {
+			return moved, err
+		}
+		moved = append(moved, name)
+	}
+	return moved, nil
+}
+
+// copyTree copies the contents of src into the existing directory dst, preserving
+// modes, symlinks and modification times. The top-level entries named in skip are left out.
+func copyTree(src, dst string, skip []string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if slices.Contains(skip, rel) {
+			return filepath.SkipDir
+		}
+		destPath := filepath.Join(dst, rel)
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case entry.IsDir():
+			if err := os.Mkdir(destPath, info.Mode().Perm()); err != nil {
+				return err
+			}
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, destPath)
+		case info.Mode().IsRegular():
+			if err := copyFile(path, destPath, info.Mode().Perm()); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("cannot stage special file %s", path)
+		}
+
+		return os.Chtimes(destPath, info.ModTime(), info.ModTime())
+	})
+}
+
+// copyFile copies a single regular file
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func(in *os.File) {
+		_ = in.Close()
+	}(in)
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newFailingGenerator returns a generator whose schemas/user.py template cannot be parsed.
+// The override directory is layered over the embedded templates, so every other template still loads.
+func newFailingGenerator(t *testing.T) *Generator {
+	t.Helper()
+
+	templateDir := filepath.Join(createTempTestDir(t), "templates")
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(templateDir)) })
+
+	brokenPath := filepath.Join(templateDir, "web", "fastapi", "schemas", "user.py.j2")
+	if err := os.MkdirAll(filepath.Dir(brokenPath), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(brokenPath, []byte("{% if %}broken"), 0644); err != nil {
+		t.Fatalf("Failed to write broken template: %v", err)
+	}
+
+	gen := New()
+	if err := gen.SetTemplateDir(templateDir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+	gen.SetDirectoryConfirmation(func(string) (bool, error) { return true, nil })
+	return gen
+}
+
+// leftovers lists staging or backup directories left next to the project
+func leftovers(t *testing.T, parent string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", parent, err)
+	}
+
+	var found []string
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".pyinit-") {
+			found = append(found, entry.Name())
+		}
+	}
+	return found
+}
+
+func TestGenerateProject_RollbackNewDirectory(t *testing.T) {
+	tempDir := createTempTestDir(t)
+	defer cleanupTestDir(t, tempDir)
+
+	cfg := createBasicTestConfig(filepath.Join(tempDir, "project"))
+	cfg.ProjectType = "web"
+	cfg.WebFramework = "fastapi"
+
+	if err := newFailingGenerator(t).GenerateProject(cfg); err == nil || !strings.Contains(err.Error(), "schemas/user.py") {
+		t.Fatalf("Expected generation to fail on the broken template, got %v", err)
+	}
+
+	if _, err := os.Stat(cfg.ProjectPath); !os.IsNotExist(err) {
+		t.Errorf("Partially generated project %s was left behind", cfg.ProjectPath)
+	}
+	if found := leftovers(t, tempDir); len(found) > 0 {
+		t.Errorf("Staging directories left behind: %v", found)
+	}
+}
+
+func TestGenerateProject_RollbackRestoresExistingDirectory(t *testing.T) {
+	tempDir := createTempTestDir(t)
+	defer cleanupTestDir(t, tempDir)
+
+	projectPath := filepath.Join(tempDir, "project")
+	for _, dir := range []string{"notes", ".git"} {
+		if err := os.MkdirAll(filepath.Join(projectPath, dir), 0755); err != nil {
+			t.Fatalf("Failed to create existing project: %v", err)
+		}
+	}
+	original := map[string]string{
+		"README.md":      "my own readme\n",
+		"notes/todo.txt": "keep me\n",
+		".git/HEAD":      "ref: refs/heads/main\n",
+	}
+	for rel, content := range original {
+		if err := os.WriteFile(filepath.Join(projectPath, rel), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", rel, err)
+		}
+	}
+
+	cfg := createBasicTestConfig(projectPath)
+	cfg.ProjectType = "web"
+	cfg.WebFramework = "fastapi"
+
+	if err := newFailingGenerator(t).GenerateProject(cfg); err == nil || !strings.Contains(err.Error(), "schemas/user.py") {
+		t.Fatalf("Expected generation to fail on the broken template, got %v", err)
+	}
+
+	var files []string
+	err := filepath.WalkDir(projectPath, func(path string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			rel, _ := filepath.Rel(projectPath, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk project: %v", err)
+	}
+	if len(files) != len(original) {
+		t.Errorf("Existing directory contents changed, now contains: %v", files)
+	}
+
+	for rel, content := range original {
+		path := filepath.Join(projectPath, rel)
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != content {
+			t.Errorf("%s = %q (err %v), want %q", rel, string(data), err, content)
+		}
+		if info, err := os.Stat(path); err == nil && info.Mode().Perm() != 0600 {
+			t.Errorf("%s mode = %v, want 0600", rel, info.Mode().Perm())
+		}
+	}
+	if found := leftovers(t, tempDir); len(found) > 0 {
+		t.Errorf("Staging directories left behind: %v", found)
+	}
+}
+
+func TestGenerateProject_CommitIntoExistingDirectory(t *testing.T) {
+	tempDir := createTempTestDir(t)
+	defer cleanupTestDir(t, tempDir)
+
+	projectPath := filepath.Join(tempDir, "project")
+	if err := os.MkdirAll(projectPath, 0755); err != nil {
+		t.Fatalf("Failed to create existing project: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectPath, "keep.txt"), []byte("keep"), 0644); err != nil {
+		t.Fatalf("Failed to write keep.txt: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(projectPath, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectPath, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .git/HEAD: %v", err)
+	}
+	gitBefore, err := os.Stat(filepath.Join(projectPath, ".git"))
+	if err != nil {
+		t.Fatalf("Failed to stat .git: %v", err)
+	}
+
+	gen := New()
+	gen.SetDirectoryConfirmation(func(string) (bool, error) { return true, nil })
+
+	if err := gen.GenerateProject(createBasicTestConfig(projectPath)); err != nil {
+		t.Fatalf("GenerateProject failed: %v", err)
+	}
+
+	for _, rel := range []string{"keep.txt", "pyproject.toml", filepath.Join("test_project", "main.py")} {
+		if _, err := os.Stat(filepath.Join(projectPath, rel)); err != nil {
+			t.Errorf("Expected %s after generation: %v", rel, err)
+		}
+	}
+	info, err := os.Stat(projectPath)
+	if err != nil {
+		t.Fatalf("Failed to stat project directory: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Project directory mode changed: %v", info.Mode().Perm())
+	}
+
+	// The repository is moved into place, not copied
+	gitAfter, err := os.Stat(filepath.Join(projectPath, ".git"))
+	if err != nil {
+		t.Fatalf("Expected .git after generation: %v", err)
+	}
+	if !os.SameFile(gitBefore, gitAfter) {
+		t.Error(".git was copied instead of moved into place")
+	}
+	if head, err := os.ReadFile(filepath.Join(projectPath, ".git", "HEAD")); err != nil || string(head) != "ref: refs/heads/main\n" {
+		t.Errorf(".git/HEAD = %q (err %v), want it kept", string(head), err)
+	}
+	if found := leftovers(t, tempDir); len(found) > 0 {
+		t.Errorf("Staging or backup directories left behind: %v", found)
+	}
+}
+
+func TestTransaction_CommitConflictRestoresCarriedDirs(t *testing.T) {
+	tempDir := createTempTestDir(t)
+	defer cleanupTestDir(t, tempDir)
+
+	projectPath := filepath.Join(tempDir, "project")
+	if err := os.MkdirAll(filepath.Join(projectPath, ".venv", "lib"), 0755); err != nil {
+		t.Fatalf("Failed to create .venv: %v", err)
+	}
+	venvBefore, err := os.Stat(filepath.Join(projectPath, ".venv"))
+	if err != nil {
+		t.Fatalf("Failed to stat .venv: %v", err)
+	}
+
+	tx, err := beginTransaction(projectPath)
+	if err != nil {
+		t.Fatalf("beginTransaction failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tx.staging, ".venv")); !os.IsNotExist(err) {
+		t.Errorf(".venv was copied into the staging directory")
+	}
+
+	// A generated .venv would be replaced by the carried one, so commit refuses
+	if err := os.Mkdir(filepath.Join(tx.staging, ".venv"), 0755); err != nil {
+		t.Fatalf("Failed to create staged .venv: %v", err)
+	}
+	if err := tx.commit(); err == nil {
+		t.Fatal("Expected commit to fail")
+	}
+
+	venvAfter, err := os.Stat(filepath.Join(projectPath, ".venv"))
+	if err != nil {
+		t.Fatalf("Expected .venv to be restored: %v", err)
+	}
+	if !os.SameFile(venvBefore, venvAfter) {
+		t.Error("The original .venv was not restored")
+	}
+	if found := leftovers(t, tempDir); len(found) > 0 {
+		t.Errorf("Staging or backup directories left behind: %v", found)
+	}
+}
@@ -1,21 +1,28 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
-	"github.com/Pradyothsp/pyinit/internal/config"
 	"os"
 	"path/filepath"
-)
 
-func (g *Generator) generateFileFromTemplate(cfg *config.ProjectConfig, templateName, relativePath string) error {
-	outputPath := filepath.Join(cfg.ProjectPath, relativePath)
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/pkg/template"
+)
 
+func (g *Generator) generateFileFromTemplate(cfg *config.ProjectConfig, templateName, relativePath string, perm os.FileMode) error {
 	content, err := g.templateEngine.RenderTemplate(templateName, cfg.TemplateContext())
 	if err != nil {
+		// Template errors already name the template and the file it failed in
+		var templateErr *template.TemplateError
+		if errors.As(err, &templateErr) {
+			templateErr.Output = filepath.Join(cfg.ProjectPath, relativePath)
+			return templateErr
+		}
 		return fmt.Errorf("failed to render %s template: %w", templateName, err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.fs.WriteFile(relativePath, []byte(content), perm, templateName); err != nil {
 		return fmt.Errorf("failed to write %s: %w", relativePath, err)
 	}
 
@@ -0,0 +1,353 @@
+// Package lint renders project templates against sample configurations and reports
+// the problems it finds in them
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/generator"
+	"github.com/Pradyothsp/pyinit/internal/prompts"
+	"github.com/Pradyothsp/pyinit/pkg/template"
+)
+
+// Issue is one problem found while linting
+type Issue struct {
+	Kind     string // Project kind being rendered, e.g. "web/fastapi"
+	Sample   string // Sample configuration the problem showed up with, e.g. "python 3.10"
+	Template string // Template the problem is in, empty when it is not tied to one
+	Path     string // Rendered output path relative to the project root
+	Message  string
+}
+
+func (i Issue) String() string {
+	var b strings.Builder
+	if i.Kind != "" {
+		b.WriteString(i.Kind + ": ")
+	}
+	if i.Template != "" {
+		b.WriteString(i.Template)
+		if i.Path != "" {
+			b.WriteString(" -> " + filepath.ToSlash(i.Path))
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(i.Message)
+	if i.Sample != "" {
+		b.WriteString(" (" + i.Sample + ")")
+	}
+	return b.String()
+}
+
+// Report is the outcome of linting a set of project kinds
+type Report struct {
+	Kinds   int      // Kinds linted
+	Samples int      // Sample configurations rendered
+	Issues  []Issue  // Problems found, in the order they were found
+	Notes   []string // Checks that could not run
+}
+
+// Sample is a configuration every kind is rendered with
+type Sample struct {
+	Name   string
+	Config *config.ProjectConfig
+}
+
+// samplePythonVersions are the Python versions of the sample matrix
+var samplePythonVersions = []string{"3.10", "3.12", "3.13"}
+
+// sampleIdentities are the project names of the sample matrix: one already a valid
+// package name and one that needs every name filter. The second also turns on every
+// feature, so both sides of features.* conditions are rendered.
+var sampleIdentities = []struct {
+	projectName, mainDirName string
+	features                 bool
+}{
+	{"lint-sample", "lint_sample", false},
+	{"Lint Sample Project", "lintsample", true},
+}
+
+// Samples returns the matrix of configurations kind is rendered with
+func Samples(kind config.ProjectKind) []Sample {
+	var samples []Sample
+	for _, identity := range sampleIdentities {
+		for _, version := range samplePythonVersions {
+			cfg := &config.ProjectConfig{
+				UserName:           "Sample Author",
+				Email:              "author@example.com",
+				ProjectName:        identity.projectName,
+				ProjectDescription: "A project rendered by pyinit template lint",
+				ProjectType:        kind.Type,
+				ProjectPath:        filepath.Join(os.TempDir(), "pyinit-lint", config.SanitizeProjectName(identity.projectName)),
+				MainDirName:        identity.mainDirName,
+				PythonVersion:      version,
+			}
+			switch kind.Type {
+			case "web":
+				cfg.WebFramework = kind.Framework
+			case "cli":
+				cfg.CLIFramework = kind.Framework
+			}
+			if identity.features {
+				cfg.Dependencies = []string{"requests"}
+				cfg.SetupEnvironment = true
+				cfg.RegisterKernel = kind.Type == "data-science"
+			}
+			// Questions a template pack declares are answered with their defaults
+			prompts.ApplyDefaults(cfg)
+
+			samples = append(samples, Sample{
+				Name:   fmt.Sprintf("%s, python %s", identity.projectName, version),
+				Config: cfg,
+			})
+		}
+	}
+	return samples
+}
+
+// contextualKeys are context values that differ between samples. Output rendered for
+// one sample that contains another sample's value has that value hard-coded.
+var contextualKeys = []string{"python_version_for_ruff", "python_version"}
+
+// Linter renders templates against the sample matrix
+type Linter struct {
+	templateDirs []string
+	python       string // Interpreter used to check Python syntax, empty to skip the check
+}
+
+// New creates a Linter for the embedded templates
+func New() *Linter {
+	l := &Linter{}
+	for _, name := range []string{"python3", "python"} {
+		if path, err := exec.LookPath(name); err == nil {
+			l.python = path
+			break
+		}
+	}
+	return l
+}
+
+// SetTemplateDir layers a template directory over the embedded templates, like
+// pyinit new --template-dir
+func (l *Linter) SetTemplateDir(dir string) {
+	l.templateDirs = append(l.templateDirs, dir)
+}
+
+// Lint renders every implemented kind in kinds with every sample and checks that
+//   - every template parses and uses only variables the context defines
+//   - rendered TOML, YAML, JSON, notebook and Python files are syntactically valid
+//   - no rendered file contains a value that should come from the context
+func (l *Linter) Lint(kinds []config.ProjectKind) (*Report, error) {
+	g := generator.New()
+	for _, dir := range l.templateDirs {
+		if err := g.SetTemplateDir(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &reporter{report: &Report{}, seen: map[string]bool{}}
+	precompiled := map[string]bool{}
+	broken := map[string]bool{} // Templates Precompile failed on, by pack directory and name
+	checkedUndefined := map[string]bool{}
+
+	for _, kind := range kinds {
+		if !kind.Implemented {
+			continue
+		}
+		r.report.Kinds++
+
+		engine, err := g.EngineFor(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		// Kinds from the same pack share their templates, so each set is parsed once
+		if !precompiled[kind.TemplateDir] {
+			precompiled[kind.TemplateDir] = true
+			for _, err := range unwrapAll(engine.Precompile()) {
+				var templateErr *template.TemplateError
+				if !errors.As(err, &templateErr) {
+					r.add(Issue{Message: err.Error()})
+					continue
+				}
+				broken[kind.TemplateDir+"\x00"+templateErr.Template] = true
+				r.add(templateIssue(Issue{}, templateErr))
+			}
+		}
+
+		samples := Samples(kind)
+		checkConditions(g, engine, kind, samples[0], r)
+
+		for _, sample := range samples {
+			r.report.Samples++
+
+			plan, err := g.PlanProject(sample.Config)
+			if err != nil {
+				issue := Issue{Kind: kind.ID(), Sample: sample.Name, Message: err.Error()}
+				var templateErr *template.TemplateError
+				if errors.As(err, &templateErr) {
+					// Precompile has already reported templates that do not parse
+					if broken[kind.TemplateDir+"\x00"+templateErr.Template] {
+						continue
+					}
+					issue = templateIssue(issue, templateErr)
+				}
+				r.add(issue)
+				continue
+			}
+
+			context := sample.Config.TemplateContext()
+			for _, file := range plan.Files() {
+				issue := Issue{Kind: kind.ID(), Sample: sample.Name, Template: file.Template, Path: file.Path}
+
+				if file.Template != "" && !checkedUndefined[kind.TemplateDir+"\x00"+file.Template] {
+					checkedUndefined[kind.TemplateDir+"\x00"+file.Template] = true
+					undefined, err := engine.UndefinedVariables(file.Template, context)
+					if err != nil {
+						r.add(issue.with(err.Error()))
+					} else if len(undefined) > 0 {
+						r.add(issue.with("undefined variables: " + strings.Join(undefined, ", ")))
+					}
+				}
+
+				if err := checkSyntax(file.Path, file.Content); err != nil {
+					r.add(issue.with(err.Error()))
+				}
+				if isPython(file.Path) {
+					r.python = append(r.python, pythonFile{issue: issue, source: file.Content})
+				}
+
+				for _, message := range hardCodedValues(string(file.Content), sample, samples) {
+					r.add(issue.with(message))
+				}
+			}
+		}
+	}
+
+	if l.python == "" {
+		r.report.Notes = append(r.report.Notes, "Python syntax was not checked: no python3 found in PATH")
+	} else if err := checkPythonSyntax(l.python, r); err != nil {
+		r.report.Notes = append(r.report.Notes, fmt.Sprintf("Python syntax was not checked: %v", err))
+	}
+
+	return r.report, nil
+}
+
+// reporter collects issues, dropping repeats of the same problem across samples
+type reporter struct {
+	report *Report
+	seen   map[string]bool
+	python []pythonFile
+}
+
+func (r *reporter) add(issue Issue) {
+	// Output paths differ between samples, so issues in templates are told apart by template
+	location := issue.Template
+	if location == "" {
+		location = issue.Path
+	}
+	key := strings.Join([]string{issue.Kind, location, issue.Message}, "\x00")
+	if r.seen[key] {
+		return
+	}
+	r.seen[key] = true
+	r.report.Issues = append(r.report.Issues, issue)
+}
+
+// with returns a copy of the issue carrying message
+func (i Issue) with(message string) Issue {
+	i.Message = message
+	return i
+}
+
+// checkConditions reports manifest conditions of kind that use variables the template
+// context does not define. Pongo2 treats those as false, so the file is always skipped.
+func checkConditions(g *generator.Generator, engine *template.Engine, kind config.ProjectKind, sample Sample, r *reporter) {
+	// A kind whose manifests do not load fails to plan, which reports the problem
+	manifests, err := g.Manifests(sample.Config)
+	if err != nil {
+		return
+	}
+
+	context := sample.Config.TemplateContext()
+	for _, m := range manifests {
+		for _, file := range m.Files {
+			if file.When == "" {
+				continue
+			}
+			issue := Issue{Kind: kind.ID(), Template: file.Template}
+			undefined, err := engine.UndefinedVariablesString("{% if "+file.When+" %}{% endif %}", context)
+			if err != nil {
+				r.add(issue.with(fmt.Sprintf("invalid condition %q for %s: %v", file.When, file.Output, err)))
+			} else if len(undefined) > 0 {
+				r.add(issue.with(fmt.Sprintf("condition %q for %s uses undefined variables: %s", file.When, file.Output, strings.Join(undefined, ", "))))
+			}
+		}
+	}
+}
+
+// templateIssue describes a template that failed to load, parse or render. The output
+// path, which differs between samples, is left out so the failure is reported once.
+func templateIssue(issue Issue, err *template.TemplateError) Issue {
+	issue.Template = err.Template
+	issue.Path = ""
+
+	location := ""
+	if err.Source != "" {
+		location = ": " + err.Source
+		if err.Line > 0 {
+			location += fmt.Sprintf(":%d:%d", err.Line, err.Column)
+		}
+	}
+	issue.Message = fmt.Sprintf("failed to %s%s: %v", err.Op, location, err.Err)
+	return issue
+}
+
+// unwrapAll splits an error joined with errors.Join back into its parts
+func unwrapAll(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// hardCodedValues reports values of the contextual keys that content, rendered for
+// sample, contains although they belong to other samples
+func hardCodedValues(content string, sample Sample, samples []Sample) []string {
+	var messages []string
+
+	own := sample.Config.TemplateContext()
+	for _, key := range contextualKeys {
+		seen := map[string]bool{fmt.Sprint(own[key]): true}
+		for _, other := range samples {
+			value := fmt.Sprint(other.Config.TemplateContext()[key])
+			if seen[value] {
+				continue
+			}
+			seen[value] = true
+
+			if containsToken(content, value) {
+				messages = append(messages, fmt.Sprintf("hard-coded %q, use {{ %s }}", value, key))
+				break
+			}
+		}
+	}
+
+	return messages
+}
+
+// containsToken reports whether value appears in content on its own, not as part of a
+// longer name or version
+func containsToken(content, value string) bool {
+	pattern := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(value) + `($|[^\w.]|\.($|\W))`)
+	return pattern.MatchString(content)
+}
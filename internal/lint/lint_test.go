@@ -0,0 +1,216 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+)
+
+func TestLint_EmbeddedTemplates(t *testing.T) {
+	report, err := New().Lint(config.ProjectKinds())
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	for _, issue := range report.Issues {
+		t.Errorf("%s", issue)
+	}
+	for _, note := range report.Notes {
+		t.Logf("Note: %s", note)
+	}
+
+	if want := len(samplePythonVersions) * len(sampleIdentities) * report.Kinds; report.Samples != want {
+		t.Errorf("Rendered %d samples, want %d", report.Samples, want)
+	}
+}
+
+func TestLint_ReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	templates := map[string]string{
+		"basic/pyproject.toml.j2": "[tool.ruff]\ntarget-version = \"py313\"\n[project\n",
+		"basic/broken.j2":         "{% if %}\n",
+		"web/fastapi/main.py.j2":  "def main(:\n    return \"{{ app_title }} {{ project.nmae }}\"\n",
+	}
+	for name, content := range templates {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	linter := New()
+	linter.SetTemplateDir(dir)
+
+	report, err := linter.Lint(config.ProjectKinds())
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	var messages []string
+	for _, issue := range report.Issues {
+		messages = append(messages, issue.String())
+	}
+	all := strings.Join(messages, "\n")
+
+	want := []string{
+		"basic/broken.j2: failed to parse",
+		"basic: basic/pyproject.toml.j2 -> pyproject.toml: invalid TOML",
+		`basic: basic/pyproject.toml.j2 -> pyproject.toml: hard-coded "py313", use {{ python_version_for_ruff }}`,
+		"web/fastapi: web/fastapi/main.py.j2 -> lint_sample/main.py: undefined variables: app_title, project.nmae",
+	}
+	if linter.python != "" {
+		want = append(want, "web/fastapi: web/fastapi/main.py.j2 -> lint_sample/main.py: invalid Python: line 1")
+	}
+	for _, message := range want {
+		if !strings.Contains(all, message) {
+			t.Errorf("Expected an issue containing %q, got:\n%s", message, all)
+		}
+	}
+
+	// The same problem in every sample is reported once
+	if count := strings.Count(all, "undefined variables: app_title"); count != 1 {
+		t.Errorf("Undefined variable reported %d times, want once", count)
+	}
+}
+
+func TestLint_BrokenTemplateReportedOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "basic", "pyproject.toml.j2")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("{% if %}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	linter := New()
+	linter.SetTemplateDir(dir)
+
+	report, err := linter.Lint([]config.ProjectKind{{Type: "basic", Dir: "basic", Implemented: true}})
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	// The parse failure shows up in Precompile and in every sample's plan
+	if len(report.Issues) != 1 {
+		t.Fatalf("Got %d issues, want 1:\n%v", len(report.Issues), report.Issues)
+	}
+	issue := report.Issues[0]
+	if issue.Template != "basic/pyproject.toml.j2" || !strings.Contains(issue.Message, "failed to parse: "+path+":1:") {
+		t.Errorf("Issue = %s, want a parse failure in basic/pyproject.toml.j2 naming its source line", issue)
+	}
+	if strings.Contains(issue.String(), "pyinit-lint") {
+		t.Errorf("Issue names an output path: %s", issue)
+	}
+}
+
+func TestLint_ReportsUndefinedConditionVariables(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "basic", "manifest.yaml")
+	manifest := `files:
+  - template: main.py.j2
+    output: main.py
+  - template: README.md.j2
+    output: README.md
+    when: project.framework == ""
+  - template: pyproject.toml.j2
+    output: pyproject.toml
+    when: cli_framework == "click"
+`
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	linter := New()
+	linter.SetTemplateDir(dir)
+
+	report, err := linter.Lint([]config.ProjectKind{{Type: "basic", Dir: "basic", Implemented: true}})
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	var conditions []string
+	for _, issue := range report.Issues {
+		if strings.Contains(issue.Message, "condition") {
+			conditions = append(conditions, issue.String())
+		}
+	}
+	want := `basic: basic/pyproject.toml.j2: condition "cli_framework == \"click\"" for pyproject.toml uses undefined variables: cli_framework`
+	if len(conditions) != 1 || conditions[0] != want {
+		t.Errorf("Condition issues = %q, want [%q]", conditions, want)
+	}
+}
+
+func TestLint_WithoutPython(t *testing.T) {
+	linter := New()
+	linter.python = ""
+
+	report, err := linter.Lint([]config.ProjectKind{{Type: "basic", Dir: "basic", Implemented: true}})
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(report.Notes) != 1 || !strings.Contains(report.Notes[0], "Python syntax was not checked") {
+		t.Errorf("Notes = %v, want a note that Python syntax was not checked", report.Notes)
+	}
+}
+
+func TestCheckSyntax(t *testing.T) {
+	tests := []struct {
+		path      string
+		content   string
+		errorText string
+	}{
+		{path: "pyproject.toml", content: "[project]\nname = \"demo\"\n"},
+		{path: "pyproject.toml", content: "[project\n", errorText: "invalid TOML"},
+		{path: ".github/workflows/ci.yml", content: "on: push\njobs:\n  test: {}\n---\nsecond: doc\n"},
+		{path: "config.yaml", content: "key: [unclosed\n", errorText: "invalid YAML"},
+		{path: "settings.json", content: "{\"a\": 1,}", errorText: "invalid JSON"},
+		{path: "notebooks/explore.ipynb", content: "{\"cells\": [], \"nbformat\": 4}"},
+		{path: "notebooks/explore.ipynb", content: "{\"cells\": [\"print(1)\",]}", errorText: "invalid JSON"},
+		{path: "README.md", content: "[project\n"},
+	}
+
+	for _, tt := range tests {
+		err := checkSyntax(tt.path, []byte(tt.content))
+		if tt.errorText == "" {
+			if err != nil {
+				t.Errorf("checkSyntax(%s, %q) error: %v", tt.path, tt.content, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+			t.Errorf("checkSyntax(%s, %q) error = %v, want error containing %q", tt.path, tt.content, err, tt.errorText)
+		}
+	}
+}
+
+func TestContainsToken(t *testing.T) {
+	tests := []struct {
+		content string
+		value   string
+		want    bool
+	}{
+		{`target-version = "py313"`, "py313", true},
+		{`target-version = "py3130"`, "py313", false},
+		{`requires-python = ">=3.13"`, "3.13", true},
+		{"Python 3.13.", "3.13", true},
+		{`version = "3.13.1"`, "3.13", false},
+		{`version = "13.13"`, "3.13", false},
+		{`target-version = "py310"`, "3.10", false},
+	}
+
+	for _, tt := range tests {
+		if got := containsToken(tt.content, tt.value); got != tt.want {
+			t.Errorf("containsToken(%q, %q) = %v, want %v", tt.content, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// checkSyntax parses rendered TOML, YAML and JSON files, including Jupyter notebooks,
+// which are JSON. Python is checked separately by checkPythonSyntax, in one interpreter
+// run for every file.
+func checkSyntax(path string, content []byte) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		var doc map[string]interface{}
+		if err := toml.Unmarshal(content, &doc); err != nil {
+			return fmt.Errorf("invalid TOML: %w", err)
+		}
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		for {
+			var doc interface{}
+			err := decoder.Decode(&doc)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("invalid YAML: %w", err)
+			}
+		}
+	case ".json", ".ipynb":
+		var doc interface{}
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+// isPython reports whether a rendered file is Python source
+func isPython(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".py")
+}
+
+// pythonFile is rendered Python source waiting for the syntax check
+type pythonFile struct {
+	issue  Issue
+	source []byte
+}
+
+// pythonSyntaxScript parses each file it reads from stdin with the ast module and
+// prints the syntax errors it finds
+const pythonSyntaxScript = `
+import ast, json, sys
+errors = []
+for index, source in enumerate(json.load(sys.stdin)):
+    try:
+        ast.parse(source)
+    except SyntaxError as e:
+        errors.append({"index": index, "line": e.lineno or 0, "message": e.msg})
+json.dump(errors, sys.stdout)
+`
+
+// pythonSyntaxError is one syntax error reported by pythonSyntaxScript
+type pythonSyntaxError struct {
+	Index   int    `json:"index"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// checkPythonSyntax parses the collected Python files with the python interpreter and
+// reports their syntax errors
+func checkPythonSyntax(python string, r *reporter) error {
+	if len(r.python) == 0 {
+		return nil
+	}
+
+	sources := make([]string, len(r.python))
+	for i, file := range r.python {
+		sources[i] = string(file.source)
+	}
+	input, err := json.Marshal(sources)
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(python, "-c", pythonSyntaxScript)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("%s failed: %w: %s", python, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var syntaxErrors []pythonSyntaxError
+	if err := json.Unmarshal(output, &syntaxErrors); err != nil {
+		return fmt.Errorf("unexpected output from %s: %w", python, err)
+	}
+
+	for _, syntaxError := range syntaxErrors {
+		if syntaxError.Index < 0 || syntaxError.Index >= len(r.python) {
+			continue
+		}
+		r.add(r.python[syntaxError.Index].issue.with(
+			fmt.Sprintf("invalid Python: line %d: %s", syntaxError.Line, syntaxError.Message)))
+	}
+
+	return nil
+}
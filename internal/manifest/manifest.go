@@ -0,0 +1,122 @@
+// Package manifest describes the files a template directory renders into a project
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the manifest every template directory carries
+const FileName = "manifest.yaml"
+
+// Manifest lists what a template directory renders into the generated project
+type Manifest struct {
+	Packages []string `yaml:"packages"` // Directories that get an empty __init__.py, templated like output paths
+	Files    []File   `yaml:"files"`
+}
+
+// File is one file rendered into the generated project
+type File struct {
+	Template string `yaml:"template"` // Template name relative to the manifest's directory; empty creates an empty file
+	Output   string `yaml:"output"`   // Output path relative to the project root, rendered with the template context
+	When     string `yaml:"when"`     // Optional condition, e.g. `project.framework == "click"`; the file is skipped when false
+	Mode     string `yaml:"mode"`     // Optional octal permissions, e.g. "0755"; defaults to 0644
+}
+
+// DefaultMode is the permission of files whose manifest entry sets no mode
+const DefaultMode os.FileMode = 0644
+
+// Parse reads a manifest and resolves its template names against dir, the manifest's
+// directory relative to the template root
+func Parse(data []byte, dir string) (*Manifest, error) {
+	var m Manifest
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		for i := range m.Files {
+			if m.Files[i].Template != "" {
+				m.Files[i].Template = path.Join(dir, m.Files[i].Template)
+			}
+		}
+	}
+
+	return &m, nil
+}
+
+// Validate checks that the manifest renders something and that every entry is usable
+func (m *Manifest) Validate() error {
+	if len(m.Files) == 0 && len(m.Packages) == 0 {
+		return fmt.Errorf("no files listed")
+	}
+
+	for i, pkg := range m.Packages {
+		if strings.TrimSpace(pkg) == "" {
+			return fmt.Errorf("package %d has no path", i+1)
+		}
+	}
+
+	for i, file := range m.Files {
+		if strings.TrimSpace(file.Output) == "" {
+			return fmt.Errorf("file %d has no output path", i+1)
+		}
+		if file.Template != "" && !IsRelativePath(file.Template) {
+			return fmt.Errorf("template %s must be a relative path inside the template root", file.Template)
+		}
+		if _, err := file.Perm(); err != nil {
+			return fmt.Errorf("%s: %w", file.Output, err)
+		}
+	}
+
+	return nil
+}
+
+// Templates returns the template names the manifest renders, in order, without duplicates
+func (m *Manifest) Templates() []string {
+	var templates []string
+	seen := map[string]bool{}
+	for _, file := range m.Files {
+		if file.Template != "" && !seen[file.Template] {
+			seen[file.Template] = true
+			templates = append(templates, file.Template)
+		}
+	}
+	return templates
+}
+
+// Perm returns the permissions the file is written with
+func (f File) Perm() (os.FileMode, error) {
+	if f.Mode == "" {
+		return DefaultMode, nil
+	}
+
+	mode, err := strconv.ParseUint(f.Mode, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("mode %q must be octal permissions such as \"0755\"", f.Mode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// IsRelativePath reports whether p is a slash-separated path that stays inside its root
+func IsRelativePath(p string) bool {
+	if p == "" || strings.Contains(p, "\\") || path.IsAbs(p) {
+		return false
+	}
+
+	cleaned := path.Clean(p)
+	return cleaned != "." && cleaned != ".." && !strings.HasPrefix(cleaned, "../")
+}
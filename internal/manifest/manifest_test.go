@@ -0,0 +1,105 @@
+package manifest
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	data := []byte(`packages:
+  - "{{ main_dir_name }}"
+files:
+  - template: README.md.j2
+    output: README.md
+  - template: manage.py.j2
+    output: manage.py
+    mode: "0755"
+  - template: commands/completion.py.j2
+    output: "{{ main_dir_name }}/commands/completion.py"
+    when: project.framework == "click"
+  - output: data/raw/.gitkeep
+`)
+
+	m, err := Parse(data, "web/django")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	if !reflect.DeepEqual(m.Packages, []string{"{{ main_dir_name }}"}) {
+		t.Errorf("Packages = %v", m.Packages)
+	}
+
+	wantTemplates := []string{"web/django/README.md.j2", "web/django/manage.py.j2", "web/django/commands/completion.py.j2"}
+	if got := m.Templates(); !reflect.DeepEqual(got, wantTemplates) {
+		t.Errorf("Templates() = %v, want %v", got, wantTemplates)
+	}
+
+	if m.Files[2].When != `project.framework == "click"` {
+		t.Errorf("When = %q", m.Files[2].When)
+	}
+	if m.Files[3].Template != "" {
+		t.Errorf("Empty file template resolved to %q, want it left empty", m.Files[3].Template)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		errorText string
+	}{
+		{name: "empty", data: "files: []\n", errorText: "no files"},
+		{name: "unknown field", data: "files:\n  - output: a\n    mdoe: \"0755\"\n", errorText: "mdoe"},
+		{name: "missing output", data: "files:\n  - template: a.j2\n", errorText: "no output path"},
+		{name: "blank package", data: "packages: [\"\"]\n", errorText: "package 1 has no path"},
+		{name: "template outside root", data: "files:\n  - template: ../a.j2\n    output: a\n", errorText: "relative path"},
+		{name: "bad mode", data: "files:\n  - output: a\n    mode: rwx\n", errorText: "octal"},
+		{name: "mode too large", data: "files:\n  - output: a\n    mode: \"4755\"\n", errorText: "octal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data), "")
+			if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Parse() error = %v, want error containing %q", err, tt.errorText)
+			}
+		})
+	}
+}
+
+func TestFilePerm(t *testing.T) {
+	tests := map[string]os.FileMode{"": DefaultMode, "0755": 0755, "600": 0600}
+
+	for mode, want := range tests {
+		got, err := File{Output: "a", Mode: mode}.Perm()
+		if err != nil {
+			t.Fatalf("Perm(%q) error: %v", mode, err)
+		}
+		if got != want {
+			t.Errorf("Perm(%q) = %o, want %o", mode, got, want)
+		}
+	}
+}
+
+func TestIsRelativePath(t *testing.T) {
+	tests := map[string]bool{
+		"README.md":          true,
+		"pkg/__init__.py":    true,
+		"pkg/../README.md":   true,
+		"":                   false,
+		".":                  false,
+		"..":                 false,
+		"../outside":         false,
+		"pkg/../../outside":  false,
+		"/absolute":          false,
+		"windows\\separator": false,
+	}
+
+	for p, want := range tests {
+		if got := IsRelativePath(p); got != want {
+			t.Errorf("IsRelativePath(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
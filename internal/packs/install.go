@@ -0,0 +1,303 @@
+package packs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/manifest"
+)
+
+// Install copies the pack at source into the pack directory. source may be a pack
+// directory, a .tar.gz/.tgz archive of one, or a local bare git repository, given as
+// a path or a file:// URL. Nothing is fetched over the network. An installed pack with
+// the same name is only replaced when force is set.
+func Install(source string, force bool) (*Pack, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create pack directory: %w", err)
+	}
+
+	// Fetch into a staging directory next to the installed packs so the final move is a rename
+	staging, err := os.MkdirTemp(dir, ".install-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	if err := fetch(source, staging); err != nil {
+		return nil, err
+	}
+
+	root, err := packRoot(staging)
+	if err != nil {
+		return nil, err
+	}
+
+	pack, err := Load(root)
+	if err != nil {
+		return nil, err
+	}
+
+	target := filepath.Join(dir, pack.Name)
+	previous := ""
+	if _, err := os.Stat(target); err == nil {
+		if !force {
+			return nil, fmt.Errorf("pack %s is already installed (use --force to replace it)", pack.Name)
+		}
+		// The installed pack is moved aside, and only deleted once the new one is in place
+		if previous, err = moveAside(dir, target); err != nil {
+			return nil, fmt.Errorf("failed to replace installed pack %s: %w", pack.Name, err)
+		}
+	}
+
+	if err := os.Rename(root, target); err != nil {
+		if previous != "" {
+			if restoreErr := os.Rename(previous, target); restoreErr != nil {
+				return nil, fmt.Errorf("failed to install pack %s: %w (previous version left at %s: %v)", pack.Name, err, previous, restoreErr)
+			}
+		}
+		return nil, fmt.Errorf("failed to install pack %s: %w", pack.Name, err)
+	}
+
+	if previous != "" {
+		if err := os.RemoveAll(previous); err != nil {
+			fmt.Printf("Warning: Failed to remove the previous version of %s at %s: %v\n", pack.Name, previous, err)
+		}
+	}
+
+	return Load(target)
+}
+
+// moveAside renames path to a free name in dir and returns that name
+func moveAside(dir, path string) (string, error) {
+	aside, err := os.MkdirTemp(dir, ".replaced-*")
+	if err != nil {
+		return "", err
+	}
+	if err := os.Remove(aside); err != nil {
+		return "", err
+	}
+	if err := os.Rename(path, aside); err != nil {
+		return "", err
+	}
+	return aside, nil
+}
+
+// fetch places the contents of source in dest
+func fetch(source, dest string) error {
+	local, ok := localPath(source)
+	if !ok {
+		return fmt.Errorf("cannot install packs from remote sources such as %s: download or clone the pack and install the local copy", source)
+	}
+	source = local
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("failed to open pack source: %w", err)
+	}
+
+	switch {
+	case info.IsDir() && fileExists(filepath.Join(source, ManifestFile)):
+		return copyDir(source, dest)
+	case info.IsDir() && isBareRepository(source):
+		return cloneRepository(source, dest)
+	case info.IsDir():
+		return fmt.Errorf("%s has no %s and is not a git repository", source, ManifestFile)
+	case strings.HasSuffix(source, ".tar.gz") || strings.HasSuffix(source, ".tgz"):
+		return extractTarGz(source, dest)
+	}
+
+	return fmt.Errorf("unsupported pack source %s (expected a directory, .tar.gz archive or bare git repository)", source)
+}
+
+// packRoot finds the directory holding the manifest: dir itself, or the single
+// top-level directory archives usually wrap their contents in
+func packRoot(dir string) (string, error) {
+	if fileExists(filepath.Join(dir, ManifestFile)) {
+		return dir, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pack: %w", err)
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		nested := filepath.Join(dir, entries[0].Name())
+		if fileExists(filepath.Join(nested, ManifestFile)) {
+			return nested, nil
+		}
+	}
+
+	return "", fmt.Errorf("pack has no %s at its root", ManifestFile)
+}
+
+// localPath returns the path source names on this machine, turning file:// URLs into
+// paths. It reports false for URLs and scp-style git addresses of other machines.
+func localPath(source string) (string, bool) {
+	if strings.HasPrefix(source, "file://") {
+		u, err := url.Parse(source)
+		if err != nil || (u.Host != "" && u.Host != "localhost") {
+			return "", false
+		}
+		return filepath.FromSlash(u.Path), true
+	}
+	if strings.Contains(source, "://") || strings.HasPrefix(source, "git@") {
+		return "", false
+	}
+	return source, true
+}
+
+// isBareRepository reports whether dir looks like a bare git repository
+func isBareRepository(dir string) bool {
+	return fileExists(filepath.Join(dir, "HEAD")) &&
+		isDir(filepath.Join(dir, "objects")) &&
+		isDir(filepath.Join(dir, "refs"))
+}
+
+// cloneRepository clones the default branch of repo into dest without its history
+func cloneRepository(repo, dest string) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is required to install packs from repositories: %w", err)
+	}
+
+	clone := filepath.Join(dest, "repo")
+	cmd := exec.Command("git", "clone", "--quiet", "--", repo, clone)
+	// Only local repositories are cloned, so git may not reach out for submodules or alternates either
+	cmd.Env = append(os.Environ(), "GIT_ALLOW_PROTOCOL=file")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone %s: %w\n%s", repo, err, strings.TrimSpace(string(output)))
+	}
+
+	if err := os.RemoveAll(filepath.Join(clone, ".git")); err != nil {
+		return fmt.Errorf("failed to remove git metadata: %w", err)
+	}
+	return nil
+}
+
+// copyDir copies the regular files and directories below src into dest, skipping .git
+func copyDir(src, dest string) error {
+	return filepath.WalkDir(src, func(p string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+
+		switch {
+		case entry.IsDir() && entry.Name() == ".git":
+			return filepath.SkipDir
+		case entry.IsDir():
+			return os.MkdirAll(target, 0755)
+		case entry.Type().IsRegular():
+			return copyFile(p, target)
+		}
+		return fmt.Errorf("unsupported file %s in pack: only regular files and directories are allowed", rel)
+	})
+}
+
+// copyFile copies one regular file, keeping its permissions
+func copyFile(src, dest string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	return writeFile(dest, in, info.Mode().Perm())
+}
+
+// extractTarGz unpacks a gzipped tarball into dest, refusing entries that would land outside it
+func extractTarGz(archive, dest string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name := strings.TrimPrefix(path.Clean(header.Name), "./")
+		if header.Typeflag == tar.TypeXGlobalHeader || name == "." {
+			continue
+		}
+		if !manifest.IsRelativePath(name) {
+			return fmt.Errorf("archive entry %s is outside the pack", header.Name)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, os.FileMode(header.Mode).Perm()|0600); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported archive entry %s: only regular files and directories are allowed", header.Name)
+		}
+	}
+}
+
+// writeFile writes r to path with the given permissions
+func writeFile(path string, r io.Reader, perm os.FileMode) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, r); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,226 @@
+// Package packs installs and loads template packs: directories of templates with a
+// manifest describing the project kinds they add
+package packs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/manifest"
+	"github.com/Pradyothsp/pyinit/internal/prompts"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the name of the manifest at the root of every pack
+const ManifestFile = "pack.yaml"
+
+// namePattern restricts pack names and project types to values safe as directory names and flags
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Manifest is the parsed pack.yaml of a template pack
+type Manifest struct {
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+	Kinds       []Kind `yaml:"kinds"`
+}
+
+// Kind is a project kind a pack adds, with the files it renders listed inline. Template
+// names are relative to the pack root.
+type Kind struct {
+	Type              string            `yaml:"type"`
+	Framework         string            `yaml:"framework"`
+	Description       string            `yaml:"description"`
+	Questions         []config.Question `yaml:"questions"`
+	manifest.Manifest `yaml:",inline"`
+}
+
+// Pack is a template pack on disk
+type Pack struct {
+	Manifest
+	Dir string
+}
+
+// Dir returns the directory packs are installed in: $XDG_DATA_HOME/pyinit/packs,
+// defaulting to ~/.local/share/pyinit/packs
+func Dir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dataHome = filepath.Join(homeDir, ".local", "share")
+	}
+
+	return filepath.Join(dataHome, "pyinit", "packs"), nil
+}
+
+// Load reads and validates the pack in dir
+func Load(dir string) (*Pack, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pack manifest: %w", err)
+	}
+
+	// Unknown keys are rejected, so a misspelled one is not silently ignored
+	var m Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+
+	pack := &Pack{Manifest: m, Dir: dir}
+	if err := pack.validate(); err != nil {
+		return nil, fmt.Errorf("invalid pack %s: %w", dir, err)
+	}
+
+	return pack, nil
+}
+
+// validate checks the manifest and that every template it lists exists in the pack
+func (p *Pack) validate() error {
+	if !namePattern.MatchString(p.Name) {
+		return fmt.Errorf("name %q must be lowercase letters, digits and dashes", p.Name)
+	}
+	if len(p.Kinds) == 0 {
+		return fmt.Errorf("no kinds listed")
+	}
+
+	seen := map[string]bool{}
+	for _, kind := range p.Kinds {
+		if !namePattern.MatchString(kind.Type) {
+			return fmt.Errorf("type %q must be lowercase letters, digits and dashes", kind.Type)
+		}
+		if kind.Framework != "" && kind.Type != "web" && kind.Type != "cli" {
+			return fmt.Errorf("%s: only web and cli kinds can name a framework", kind.Type)
+		}
+
+		id := kind.projectKind(p).ID()
+		if seen[id] {
+			return fmt.Errorf("kind %s is listed twice", id)
+		}
+		seen[id] = true
+
+		if err := kind.Manifest.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+		if err := prompts.ValidateQuestions(kind.Questions); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+		for _, name := range kind.Manifest.Templates() {
+			if _, err := os.Stat(filepath.Join(p.Dir, filepath.FromSlash(name))); err != nil {
+				return fmt.Errorf("%s: template %s not found in pack", id, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ProjectKinds returns the pack's kinds as registry entries
+func (p *Pack) ProjectKinds() []config.ProjectKind {
+	kinds := make([]config.ProjectKind, 0, len(p.Kinds))
+	for i := range p.Kinds {
+		kinds = append(kinds, p.Kinds[i].projectKind(p))
+	}
+	return kinds
+}
+
+// projectKind converts a manifest kind into a registry entry
+func (k *Kind) projectKind(p *Pack) config.ProjectKind {
+	return config.ProjectKind{
+		Type:        k.Type,
+		Framework:   k.Framework,
+		Implemented: true,
+		Pack:        p.Name,
+		Description: k.Description,
+		TemplateDir: p.Dir,
+		Manifest:    &k.Manifest,
+		Questions:   k.Questions,
+	}
+}
+
+// List loads every installed pack, sorted by name. Packs that fail to load are
+// skipped and reported together in the returned error.
+func List() ([]*Pack, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pack directory: %w", err)
+	}
+
+	var packs []*Pack
+	var problems []error
+	for _, entry := range entries {
+		// Dot directories are in-progress installs
+		if !entry.IsDir() || entry.Name()[0] == '.' {
+			continue
+		}
+
+		pack, err := Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		packs = append(packs, pack)
+	}
+
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, errors.Join(problems...)
+}
+
+// Remove uninstalls the named pack
+func Remove(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid pack name %q", name)
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(dir, name)
+	if _, err := os.Stat(target); err != nil {
+		return fmt.Errorf("pack %s is not installed", name)
+	}
+
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove pack %s: %w", name, err)
+	}
+	return nil
+}
+
+// Register adds the kinds of every installed pack to the project kind registry
+func Register() error {
+	packs, err := List()
+
+	var problems []error
+	if err != nil {
+		problems = append(problems, err)
+	}
+	for _, pack := range packs {
+		for _, kind := range pack.ProjectKinds() {
+			if err := config.RegisterKind(kind); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
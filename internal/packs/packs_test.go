@@ -0,0 +1,388 @@
+package packs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+)
+
+const testManifest = `name: acme
+version: "1.0.0"
+description: ACME templates
+kinds:
+  - type: acme-service
+    description: Internal HTTP service
+    questions:
+      - id: team_name
+        message: Owning team
+        required: true
+        pattern: "^[a-z][a-z0-9-]*$"
+      - id: tier
+        type: select
+        message: Deployment tier
+        choices: [dev, staging, production]
+        default: staging
+      - id: paging
+        type: confirm
+        message: Page the on-call engineer?
+        when: answers.tier == "production"
+    files:
+      - template: service/README.md.j2
+        output: README.md
+      - output: "{{ main_dir_name }}/__init__.py"
+`
+
+// writePack creates a pack directory with the given files
+func writePack(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create pack dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write pack file: %v", err)
+		}
+	}
+	return dir
+}
+
+// validPack creates the pack described by testManifest
+func validPack(t *testing.T) string {
+	return writePack(t, map[string]string{
+		ManifestFile:           testManifest,
+		"service/README.md.j2": "# {{ project_name }}\n",
+	})
+}
+
+// useDataHome points the pack directory at a temporary data home
+func useDataHome(t *testing.T) string {
+	t.Helper()
+
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	return filepath.Join(dataHome, "pyinit", "packs")
+}
+
+func TestDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("Dir() error: %v", err)
+	}
+	if dir != filepath.Join("/data", "pyinit", "packs") {
+		t.Errorf("Dir() = %q", dir)
+	}
+
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/demo")
+	dir, err = Dir()
+	if err != nil {
+		t.Fatalf("Dir() error: %v", err)
+	}
+	if dir != filepath.Join("/home/demo", ".local", "share", "pyinit", "packs") {
+		t.Errorf("Dir() = %q", dir)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	pack, err := Load(validPack(t))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if pack.Name != "acme" || pack.Version != "1.0.0" {
+		t.Errorf("Load() = %+v", pack.Manifest)
+	}
+
+	kinds := pack.ProjectKinds()
+	if len(kinds) != 1 {
+		t.Fatalf("ProjectKinds() returned %d kinds, want 1", len(kinds))
+	}
+	kind := kinds[0]
+	if kind.ID() != "acme-service" || kind.Pack != "acme" || kind.TemplateDir != pack.Dir || !kind.Implemented {
+		t.Errorf("ProjectKinds()[0] = %+v", kind)
+	}
+	if templates := kind.Manifest.Templates(); len(templates) != 1 || templates[0] != "service/README.md.j2" {
+		t.Errorf("Templates = %v, want [service/README.md.j2]", templates)
+	}
+	if len(kind.Questions) != 3 || kind.Questions[1].ID != "tier" || kind.Questions[1].Default != "staging" {
+		t.Errorf("Questions = %+v, want team_name, tier and paging", kind.Questions)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifest  string
+		errorText string
+	}{
+		{name: "bad name", manifest: "name: Acme Pack\nkinds: []\n", errorText: "name"},
+		{name: "no kinds", manifest: "name: acme\n", errorText: "no kinds"},
+		{
+			name:      "framework on a custom type",
+			manifest:  "name: acme\nkinds:\n  - type: service\n    framework: flask\n    files:\n      - output: a\n",
+			errorText: "only web and cli kinds",
+		},
+		{
+			name:      "duplicate kind",
+			manifest:  "name: acme\nkinds:\n  - type: service\n    files: [{output: a}]\n  - type: service\n    files: [{output: a}]\n",
+			errorText: "listed twice",
+		},
+		{
+			name:      "no files",
+			manifest:  "name: acme\nkinds:\n  - type: service\n",
+			errorText: "no files",
+		},
+		{
+			name:      "missing template",
+			manifest:  "name: acme\nkinds:\n  - type: service\n    files:\n      - template: missing.j2\n        output: a\n",
+			errorText: "template missing.j2 not found",
+		},
+		{
+			name:      "invalid question",
+			manifest:  "name: acme\nkinds:\n  - type: service\n    questions:\n      - id: tier\n        type: select\n        message: Tier\n    files: [{output: a}]\n",
+			errorText: "service: question tier: select questions need choices",
+		},
+		{name: "invalid yaml", manifest: "name: [", errorText: "failed to parse"},
+		{name: "unknown key", manifest: "name: acme\ndescripton: Acme\n", errorText: "field descripton not found"},
+		{
+			name:      "unknown file key",
+			manifest:  "name: acme\nkinds:\n  - type: service\n    files:\n      - outptu: a\n",
+			errorText: "field outptu not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writePack(t, map[string]string{ManifestFile: tt.manifest}))
+			if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Load() error = %v, want error containing %q", err, tt.errorText)
+			}
+		})
+	}
+}
+
+func TestInstallFromDirectory(t *testing.T) {
+	packsDir := useDataHome(t)
+	source := validPack(t)
+
+	pack, err := Install(source, false)
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if pack.Dir != filepath.Join(packsDir, "acme") {
+		t.Errorf("Installed to %s, want %s", pack.Dir, filepath.Join(packsDir, "acme"))
+	}
+	if _, err := os.Stat(filepath.Join(pack.Dir, "service", "README.md.j2")); err != nil {
+		t.Errorf("Expected templates to be copied: %v", err)
+	}
+
+	// Installing again needs force
+	if _, err := Install(source, false); err == nil || !strings.Contains(err.Error(), "already installed") {
+		t.Errorf("Install() twice error = %v, want already installed error", err)
+	}
+	if _, err := Install(source, true); err != nil {
+		t.Errorf("Install() with force error: %v", err)
+	}
+
+	// No staging directories are left behind
+	entries, err := os.ReadDir(packsDir)
+	if err != nil {
+		t.Fatalf("Failed to read packs dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Packs dir has %d entries, want only the installed pack", len(entries))
+	}
+}
+
+func TestInstallFromTarball(t *testing.T) {
+	useDataHome(t)
+
+	archive := filepath.Join(t.TempDir(), "acme.tar.gz")
+	writeTarGz(t, archive, map[string]string{
+		"acme-1.0.0/" + ManifestFile:      testManifest,
+		"acme-1.0.0/service/README.md.j2": "# {{ project_name }}\n",
+	})
+
+	pack, err := Install(archive, false)
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if filepath.Base(pack.Dir) != "acme" {
+		t.Errorf("Installed to %s, want a directory named after the pack", pack.Dir)
+	}
+}
+
+func TestInstallFromTarballRejectsEscapingEntries(t *testing.T) {
+	packsDir := useDataHome(t)
+
+	archive := filepath.Join(t.TempDir(), "evil.tgz")
+	writeTarGz(t, archive, map[string]string{"../evil.txt": "boom"})
+
+	if _, err := Install(archive, false); err == nil || !strings.Contains(err.Error(), "outside the pack") {
+		t.Fatalf("Install() error = %v, want outside the pack error", err)
+	}
+	if _, err := os.Stat(filepath.Join(packsDir, "evil.txt")); !os.IsNotExist(err) {
+		t.Error("Archive entry was written outside the staging directory")
+	}
+}
+
+func TestInstallFromBareRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	useDataHome(t)
+
+	work := validPack(t)
+	bare := filepath.Join(t.TempDir(), "acme.git")
+	runGit(t, work, "init", "--quiet")
+	runGit(t, work, "add", ".")
+	runGit(t, work, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "Add pack")
+	runGit(t, work, "clone", "--quiet", "--bare", work, bare)
+
+	pack, err := Install(bare, false)
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(pack.Dir, ".git")); !os.IsNotExist(err) {
+		t.Error("Expected git metadata to be removed from the installed pack")
+	}
+
+	if _, err := Install("file://"+filepath.ToSlash(bare), true); err != nil {
+		t.Errorf("Install(file:// URL) error: %v", err)
+	}
+}
+
+func TestInstallRejectsRemoteSources(t *testing.T) {
+	useDataHome(t)
+
+	for _, source := range []string{
+		"https://example.com/acme-templates.git",
+		"ssh://git@example.com/acme-templates.git",
+		"git@example.com:acme/templates.git",
+		"file://example.com/srv/acme-templates.git",
+	} {
+		if _, err := Install(source, false); err == nil || !strings.Contains(err.Error(), "remote sources") {
+			t.Errorf("Install(%s) error = %v, want remote sources rejected", source, err)
+		}
+	}
+}
+
+func TestInstallUnsupportedSource(t *testing.T) {
+	useDataHome(t)
+
+	if _, err := Install(t.TempDir(), false); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("Install(empty dir) error = %v", err)
+	}
+
+	zip := filepath.Join(t.TempDir(), "pack.zip")
+	if err := os.WriteFile(zip, []byte("zip"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := Install(zip, false); err == nil || !strings.Contains(err.Error(), "unsupported pack source") {
+		t.Errorf("Install(zip) error = %v", err)
+	}
+}
+
+func TestListAndRemove(t *testing.T) {
+	packsDir := useDataHome(t)
+
+	packs, err := List()
+	if err != nil || len(packs) != 0 {
+		t.Fatalf("List() with no pack directory = %v, %v", packs, err)
+	}
+
+	if _, err := Install(validPack(t), false); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+
+	// A broken pack is reported without hiding the valid ones
+	if err := os.MkdirAll(filepath.Join(packsDir, "broken"), 0755); err != nil {
+		t.Fatalf("Failed to create broken pack: %v", err)
+	}
+
+	packs, err = List()
+	if err == nil {
+		t.Error("Expected List() to report the broken pack")
+	}
+	if len(packs) != 1 || packs[0].Name != "acme" {
+		t.Fatalf("List() = %v, want the acme pack", packs)
+	}
+
+	if err := Remove("acme"); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if err := Remove("acme"); err == nil {
+		t.Error("Expected error removing a pack that is not installed")
+	}
+	if err := Remove("../acme"); err == nil {
+		t.Error("Expected error for an invalid pack name")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	useDataHome(t)
+	t.Cleanup(func() { config.UnregisterPack("acme") })
+
+	if _, err := Install(validPack(t), false); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if err := Register(); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	kind, err := (&config.ProjectConfig{ProjectType: "acme-service"}).Kind()
+	if err != nil {
+		t.Fatalf("Kind() error: %v", err)
+	}
+	if kind.Pack != "acme" {
+		t.Errorf("Kind().Pack = %q, want acme", kind.Pack)
+	}
+}
+
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to write archive header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write archive entry: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+}
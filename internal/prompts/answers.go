@@ -0,0 +1,170 @@
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Answer IDs that are not part of buildCompleteQuestionFlow
+const (
+	DependenciesAnswerID   = "dependencies"
+	SetupEnvAnswerID       = "setupenv"
+	RegisterKernelAnswerID = "registerkernel"
+	ParentDirAnswerID      = "parentdir"
+	PackAnswersID          = "answers" // Answers to questions declared by template packs, by question ID
+)
+
+// Answers holds pre-recorded answers loaded from an answers file
+type Answers struct {
+	values            map[string]string
+	dependencies      []string
+	hasDeps           bool
+	setupEnv          bool
+	hasSetupEnv       bool
+	registerKernel    bool
+	hasRegisterKernel bool
+	packAnswers       map[string]interface{}
+}
+
+// LoadAnswers reads an answers file in YAML, JSON or TOML format, chosen by extension
+func LoadAnswers(path string) (*Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+
+	raw := map[string]interface{}{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported answers file format %q (use .yaml, .json or .toml)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse answers file %s: %w", path, err)
+	}
+
+	return ParseAnswers(raw)
+}
+
+// ParseAnswers validates raw decoded answers and converts them to their expected types
+func ParseAnswers(raw map[string]interface{}) (*Answers, error) {
+	answers := &Answers{values: map[string]string{}}
+
+	questionIDs := map[string]bool{}
+	for _, step := range buildCompleteQuestionFlow() {
+		questionIDs[step.ID] = true
+	}
+
+	for key, value := range raw {
+		switch {
+		case key == DependenciesAnswerID:
+			deps, err := stringList(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s answer: %w", key, err)
+			}
+			answers.dependencies = deps
+			answers.hasDeps = true
+		case key == SetupEnvAnswerID:
+			setupEnv, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s answer: must be true or false", key)
+			}
+			answers.setupEnv = setupEnv
+			answers.hasSetupEnv = true
+		case key == RegisterKernelAnswerID:
+			registerKernel, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s answer: must be true or false", key)
+			}
+			answers.registerKernel = registerKernel
+			answers.hasRegisterKernel = true
+		case key == PackAnswersID:
+			packAnswers, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid %s answer: must map question IDs to answers", key)
+			}
+			answers.packAnswers = packAnswers
+		case key == ParentDirAnswerID || questionIDs[key]:
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s answer: must be a string (quote values such as versions)", key)
+			}
+			answers.values[key] = str
+		default:
+			return nil, unknownQuestionError(key)
+		}
+	}
+
+	return answers, nil
+}
+
+// Value returns the recorded answer for a question ID
+func (a *Answers) Value(questionID string) (string, bool) {
+	if a == nil {
+		return "", false
+	}
+	value, ok := a.values[questionID]
+	return value, ok
+}
+
+// Dependencies returns the recorded dependency selection
+func (a *Answers) Dependencies() ([]string, bool) {
+	if a == nil {
+		return nil, false
+	}
+	return a.dependencies, a.hasDeps
+}
+
+// SetupEnvironment returns the recorded environment-setup choice
+func (a *Answers) SetupEnvironment() (bool, bool) {
+	if a == nil {
+		return false, false
+	}
+	return a.setupEnv, a.hasSetupEnv
+}
+
+// RegisterKernel returns the recorded Jupyter kernel registration choice
+func (a *Answers) RegisterKernel() (bool, bool) {
+	if a == nil {
+		return false, false
+	}
+	return a.registerKernel, a.hasRegisterKernel
+}
+
+// PackAnswers returns the recorded answers to questions declared by template packs
+func (a *Answers) PackAnswers() map[string]interface{} {
+	if a == nil {
+		return nil
+	}
+	return a.packAnswers
+}
+
+// stringList converts a decoded list value into a slice of strings
+func stringList(value interface{}) ([]string, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a list of strings")
+	}
+
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a list of strings")
+		}
+		result = append(result, str)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package prompts
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/pkg/ui"
+)
+
+// settingQuestions maps the defaults in the user config to the questions they pre-fill
+var settingQuestions = map[string]string{
+	"defaults.author":         "username",
+	"defaults.email":          "email",
+	"defaults.python_version": "pythonversion",
+	"defaults.project_type":   "projecttype",
+}
+
+// gitIdentity maps questions to the git settings offered when the user config has no default
+var gitIdentity = map[string]string{
+	"username": "user.name",
+	"email":    "user.email",
+}
+
+// UserDefaults returns the answers the prompts offer by default, keyed by question ID:
+// the defaults from the user config, falling back to the global git identity for the
+// author's name and email. settings may be nil.
+func UserDefaults(settings *ui.Config) map[string]string {
+	defaults := map[string]string{}
+	if settings != nil {
+		for key, questionID := range settingQuestions {
+			if value, _ := settings.Get(key); value != "" {
+				defaults[questionID] = value
+			}
+		}
+	}
+
+	for questionID, gitKey := range gitIdentity {
+		if defaults[questionID] == "" {
+			if value := gitConfig(gitKey); value != "" {
+				defaults[questionID] = value
+			}
+		}
+	}
+
+	return defaults
+}
+
+// gitConfig reads a setting from the global git config, returning "" when git is not
+// installed or the setting is missing
+func gitConfig(key string) string {
+	output, err := exec.Command("git", "config", "--global", "--get", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// ValidateSetting checks a default from the user config the way the prompt it pre-fills
+// checks answers. Settings that pre-fill no prompt are not checked.
+func ValidateSetting(key, value string) error {
+	questionID, ok := settingQuestions[key]
+	if !ok || value == "" {
+		return nil
+	}
+
+	for _, step := range buildCompleteQuestionFlow() {
+		if step.ID == questionID {
+			return validateAnswer(step, value)
+		}
+	}
+	return nil
+}
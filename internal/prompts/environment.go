@@ -49,3 +49,76 @@ func AskForFastAPIDependencies() ([]string, error) {
 
 	return selectedDeps, nil
 }
+
+// AskForFlaskDependencies prompts the user to select Flask dependencies they want to install
+func AskForFlaskDependencies() ([]string, error) {
+	var selectedDeps []string
+
+	availableDeps := []string{
+		"flask",
+		"python-dotenv",
+		"flask-sqlalchemy",
+		"flask-migrate",
+		"flask-cors",
+		"flask-login",
+		"flask-wtf",
+		"gunicorn",
+	}
+
+	prompt := &survey.MultiSelect{
+		Message: "Select Flask dependencies to install:",
+		Options: availableDeps,
+		Default: []string{"flask", "python-dotenv"}, // Core dependencies selected by default
+		Help:    "Use space to select/deselect, Enter to confirm",
+	}
+
+	if err := survey.AskOne(prompt, &selectedDeps); err != nil {
+		return nil, fmt.Errorf("failed to get Flask dependencies selection: %w", err)
+	}
+
+	return selectedDeps, nil
+}
+
+// AskForDataScienceDependencies prompts the user to select data-science libraries they want to install
+func AskForDataScienceDependencies() ([]string, error) {
+	var selectedDeps []string
+
+	availableDeps := []string{
+		"pandas",
+		"numpy",
+		"matplotlib",
+		"jupyter",
+		"scikit-learn",
+		"seaborn",
+		"scipy",
+		"polars",
+	}
+
+	prompt := &survey.MultiSelect{
+		Message: "Select data-science dependencies to install:",
+		Options: availableDeps,
+		Default: []string{"pandas", "numpy", "matplotlib", "jupyter"}, // Core stack selected by default
+		Help:    "Use space to select/deselect, Enter to confirm",
+	}
+
+	if err := survey.AskOne(prompt, &selectedDeps); err != nil {
+		return nil, fmt.Errorf("failed to get data-science dependencies selection: %w", err)
+	}
+
+	return selectedDeps, nil
+}
+
+// AskForKernelRegistration prompts the user whether to register a Jupyter kernel for the project
+func AskForKernelRegistration(kernelName string) (bool, error) {
+	registerKernel := false
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Register a Jupyter kernel named %q for this project?", kernelName),
+		Default: true,
+	}
+
+	if err := survey.AskOne(prompt, &registerKernel); err != nil {
+		return false, fmt.Errorf("failed to get kernel registration confirmation: %w", err)
+	}
+
+	return registerKernel, nil
+}
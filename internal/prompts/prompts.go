@@ -1,45 +1,62 @@
 package prompts
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/internal/validation"
 )
 
 // QuestionStep represents one question in our flow
 type QuestionStep struct {
 	ID        string                           // Unique identifier for this question
+	Label     string                           // Name of the answer on the review screen
 	Question  *survey.Question                 // The actual survey question
 	Condition func(*config.ProjectConfig) bool // Function that returns true if we should ask this question
 	Required  bool                             // Whether this question is mandatory
+
+	// Default derives the answer offered by default from earlier answers, replacing the
+	// prompt's fixed default
+	Default func(*config.ProjectConfig) string
 }
 
-// CollectProjectInfo gathers all necessary information from the user
-func CollectProjectInfo() (*config.ProjectConfig, error) {
+// CollectProjectInfo gathers all necessary information from the user.
+// Questions already answered in answers are not asked; answers may be nil. Prompts offer
+// the answers in defaults, keyed by question ID, as their defaults (see UserDefaults).
+// The answers are reviewed separately, once the setup choices are made (see ReviewProjectInfo).
+func CollectProjectInfo(answers *Answers, defaults map[string]string) (*config.ProjectConfig, error) {
 	cfg := &config.ProjectConfig{}
 
 	// Collect all info using a question builder pattern
-	if err := collectAllDetails(cfg); err != nil {
+	if err := collectAllDetails(cfg, answers, defaults); err != nil {
+		return nil, fmt.Errorf("failed to collect details: %w", err)
+	}
+
+	// Ask the extra questions a template pack declares for the selected kind
+	if err := collectKindAnswers(cfg, answers); err != nil {
 		return nil, fmt.Errorf("failed to collect details: %w", err)
 	}
 
+	// A recorded parent directory replaces the location prompts
+	if parentDir, ok := answers.Value(ParentDirAnswerID); ok {
+		cfg.ProjectPath = filepath.Join(parentDir, config.SanitizeProjectName(cfg.ProjectName))
+		return cfg, nil
+	}
+
 	// Set a project path based on collected info
 	if err := setProjectPath(cfg); err != nil {
 		return nil, fmt.Errorf("failed to set project path: %w", err)
 	}
 
-	// Confirm project location
-	if err := confirmProjectLocation(cfg); err != nil {
-		return nil, fmt.Errorf("failed to confirm project location: %w", err)
-	}
-
 	return cfg, nil
 }
 
-func collectAllDetails(cfg *config.ProjectConfig) error {
+func collectAllDetails(cfg *config.ProjectConfig, answers *Answers, defaults map[string]string) error {
 	allQuestions := buildCompleteQuestionFlow()
 
 	for _, step := range allQuestions {
@@ -48,17 +65,21 @@ func collectAllDetails(cfg *config.ProjectConfig) error {
 			continue // Skip this question
 		}
 
-		// Use a string type instead of interface{}
-		var answer string
-
-		// Handle a nil validator case
-		var options []survey.AskOpt
-		if step.Question.Validate != nil {
-			options = append(options, survey.WithValidator(step.Question.Validate))
+		// Use the recorded answer instead of prompting, if there is one
+		if answer, ok := answers.Value(step.ID); ok {
+			if err := validateAnswer(step, answer); err != nil {
+				return &ValidationError{QuestionID: step.ID, Err: err}
+			}
+			if err := updateConfigFromAnswer(cfg, step.ID, answer); err != nil {
+				return fmt.Errorf("failed to process %s: %w", step.ID, err)
+			}
+			continue
 		}
 
-		if err := survey.AskOne(step.Question.Prompt, &answer, options...); err != nil {
-			return fmt.Errorf("failed to collect %s: %w", step.ID, err)
+		// Offer the user's default, or the default derived from earlier answers
+		answer, err := askStep(step, offeredDefault(step, cfg, defaults))
+		if err != nil {
+			return err
 		}
 
 		// Immediately update config so next questions can use this info
@@ -67,12 +88,51 @@ func collectAllDetails(cfg *config.ProjectConfig) error {
 		}
 	}
 
-	// Print the collected configuration for debugging
-	fmt.Printf("Collected Configuration: %+v\n", cfg)
-
 	return nil
 }
 
+// offeredDefault returns the user's default for step, or the default derived from the
+// answers in cfg; when both are empty the prompt's own default is offered
+func offeredDefault(step QuestionStep, cfg *config.ProjectConfig, defaults map[string]string) string {
+	if def := defaults[step.ID]; def != "" {
+		return def
+	}
+	if step.Default != nil {
+		return step.Default(cfg)
+	}
+	return ""
+}
+
+// askStep prompts for the answer to step, offering def instead of the prompt's own
+// default when it is set
+func askStep(step QuestionStep, def string) (string, error) {
+	if def != "" {
+		switch prompt := step.Question.Prompt.(type) {
+		case *survey.Input:
+			prompt.Default = def
+		case *survey.Select:
+			if slices.Contains(prompt.Options, def) {
+				prompt.Default = def
+			}
+		}
+	}
+
+	// Use a string type instead of interface{}
+	var answer string
+
+	// Handle a nil validator case
+	var options []survey.AskOpt
+	if step.Question.Validate != nil {
+		options = append(options, survey.WithValidator(step.Question.Validate))
+	}
+
+	if err := survey.AskOne(step.Question.Prompt, &answer, options...); err != nil {
+		return "", fmt.Errorf("failed to collect %s: %w", step.ID, err)
+	}
+
+	return answer, nil
+}
+
 // Helper function to update config based on question ID
 func updateConfigFromAnswer(cfg *config.ProjectConfig, questionID string, answer string) error {
 	switch questionID {
@@ -86,6 +146,8 @@ func updateConfigFromAnswer(cfg *config.ProjectConfig, questionID string, answer
 		cfg.ProjectType = answer
 	case "webframework":
 		cfg.WebFramework = answer
+	case "cliframework":
+		cfg.CLIFramework = answer
 	case "maindirname":
 		cfg.MainDirName = answer
 	case "description":
@@ -93,17 +155,23 @@ func updateConfigFromAnswer(cfg *config.ProjectConfig, questionID string, answer
 	case "pythonversion":
 		cfg.PythonVersion = answer
 	default:
-		return fmt.Errorf("unknown question ID: %s", questionID)
+		return unknownQuestionError(questionID)
 	}
 
 	return nil
 }
 
+// unknownQuestionError reports a question ID that is not part of the flow
+func unknownQuestionError(questionID string) error {
+	return fmt.Errorf("unknown question ID: %s", questionID)
+}
+
 func buildCompleteQuestionFlow() []QuestionStep {
 	return []QuestionStep{
 		// User Details
 		{
 			ID:        "username",
+			Label:     "Author",
 			Required:  true,
 			Condition: nil,
 			Question: &survey.Question{
@@ -114,6 +182,7 @@ func buildCompleteQuestionFlow() []QuestionStep {
 		},
 		{
 			ID:        "email",
+			Label:     "Email",
 			Required:  true,
 			Condition: nil,
 			Question: &survey.Question{
@@ -126,16 +195,18 @@ func buildCompleteQuestionFlow() []QuestionStep {
 		// Project Details
 		{
 			ID:        "projectname",
+			Label:     "Project name",
 			Required:  true,
 			Condition: nil,
 			Question: &survey.Question{
 				Name:     "projectname",
 				Prompt:   &survey.Input{Message: "Enter project name:"},
-				Validate: survey.Required,
+				Validate: validateWith(validation.ProjectName),
 			},
 		},
 		{
 			ID:        "projecttype",
+			Label:     "Project type",
 			Required:  true,
 			Condition: nil,
 			Question: &survey.Question{
@@ -149,6 +220,7 @@ func buildCompleteQuestionFlow() []QuestionStep {
 		},
 		{
 			ID:       "webframework",
+			Label:    "Web framework",
 			Required: false,
 			Condition: func(cfg *config.ProjectConfig) bool {
 				return cfg.ProjectType == "web"
@@ -162,18 +234,40 @@ func buildCompleteQuestionFlow() []QuestionStep {
 				},
 			},
 		},
+		{
+			ID:       "cliframework",
+			Label:    "CLI framework",
+			Required: false,
+			Condition: func(cfg *config.ProjectConfig) bool {
+				return cfg.ProjectType == "cli"
+			},
+			Question: &survey.Question{
+				Name: "cliframework",
+				Prompt: &survey.Select{
+					Message: "Select CLI framework:",
+					Options: config.CLIFrameworks(),
+					Default: "typer",
+				},
+			},
+		},
 		{
 			ID:        "maindirname",
+			Label:     "Package",
 			Required:  true,
 			Condition: nil,
+			Default:   defaultMainDirName,
 			Question: &survey.Question{
-				Name:     "maindirname",
-				Prompt:   &survey.Input{Message: "Enter Main Directory Name:"},
-				Validate: survey.Required,
+				Name: "maindirname",
+				Prompt: &survey.Input{
+					Message: "Enter Main Directory Name:",
+					Help:    "The package your code is imported from, derived from the project name by default",
+				},
+				Validate: validateWith(validation.PackageName),
 			},
 		},
 		{
 			ID:        "description",
+			Label:     "Description",
 			Required:  true,
 			Condition: nil,
 			Question: &survey.Question{
@@ -186,6 +280,7 @@ func buildCompleteQuestionFlow() []QuestionStep {
 		},
 		{
 			ID:        "pythonversion",
+			Label:     "Python version",
 			Required:  true,
 			Condition: nil,
 			Question: &survey.Question{
@@ -193,12 +288,26 @@ func buildCompleteQuestionFlow() []QuestionStep {
 				Prompt: &survey.Input{
 					Message: "Enter Python version (default is 3.13):",
 					Default: "3.13",
+					Help:    fmt.Sprintf("Python 3.%d to 3.%d are supported", validation.MinPythonMinor, validation.MaxPythonMinor),
 				},
+				Validate: validateWith(validation.PythonVersion),
 			},
 		},
 	}
 }
 
+// defaultMainDirName derives the package name from the project name, using the suggested
+// correction when the derived name is a keyword or shadows a standard library module
+func defaultMainDirName(cfg *config.ProjectConfig) string {
+	name := config.DefaultMainDirName(cfg.ProjectName)
+
+	var validationErr *validation.Error
+	if errors.As(validation.PackageName(name), &validationErr) {
+		return validationErr.Suggestion
+	}
+	return name
+}
+
 func setProjectPath(cfg *config.ProjectConfig) error {
 	// Get the current working directory
 	cwd, err := os.Getwd()
@@ -229,34 +338,6 @@ func ConfirmDirectoryCreation(path string) (bool, error) {
 	return true, nil
 }
 
-func confirmProjectLocation(cfg *config.ProjectConfig) error {
-	// Get an absolute path for display
-	absPath, err := filepath.Abs(cfg.ProjectPath)
-	if err != nil {
-		return fmt.Errorf("failed to get absolute path: %w", err)
-	}
-
-	// Ask if the user wants to create a project in the current directory
-	createHere := false
-	prompt := &survey.Confirm{
-		Message: fmt.Sprintf("Create project in current directory?\nProject will be created at: %s", absPath),
-		Default: true,
-	}
-
-	if err := survey.AskOne(prompt, &createHere); err != nil {
-		return fmt.Errorf("failed to get directory confirmation: %w", err)
-	}
-
-	// If a user doesn't want to create here, ask for a custom path
-	if !createHere {
-		if err := askForCustomPath(cfg); err != nil {
-			return fmt.Errorf("failed to get custom path: %w", err)
-		}
-	}
-
-	return nil
-}
-
 func askForCustomPath(cfg *config.ProjectConfig) error {
 	// Get the current working directory as default
 	cwd, err := os.Getwd()
@@ -275,8 +356,18 @@ func askForCustomPath(cfg *config.ProjectConfig) error {
 		return fmt.Errorf("failed to get custom directory: %w", err)
 	}
 
-	// Create the full project path by combining custom directory + project name
+	// The directory defaults to the project slug, but can be named differently
 	projectDir := config.SanitizeProjectName(cfg.ProjectName)
+	dirPrompt := &survey.Input{
+		Message: "Enter project directory name:",
+		Default: projectDir,
+	}
+
+	if err := survey.AskOne(dirPrompt, &projectDir, survey.WithValidator(survey.Required)); err != nil {
+		return fmt.Errorf("failed to get project directory: %w", err)
+	}
+
+	// Create the full project path by combining custom directory + project directory
 	cfg.ProjectPath = filepath.Join(customDir, projectDir)
 
 	return nil
@@ -1,13 +1,16 @@
 package prompts
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/pkg/ui"
 )
 
 // Test the email validation function
@@ -181,6 +184,13 @@ func TestUpdateConfigFromAnswer(t *testing.T) {
 			expectErr:  false,
 			checkFunc:  func(c *config.ProjectConfig) bool { return c.WebFramework == "fastapi" },
 		},
+		{
+			name:       "update cli framework",
+			questionID: "cliframework",
+			answer:     "click",
+			expectErr:  false,
+			checkFunc:  func(c *config.ProjectConfig) bool { return c.CLIFramework == "click" },
+		},
 		{
 			name:       "update main dir name",
 			questionID: "maindirname",
@@ -323,6 +333,7 @@ func TestBuildCompleteQuestionFlow(t *testing.T) {
 		"projectname",
 		"projecttype",
 		"webframework",
+		"cliframework",
 		"maindirname",
 		"description",
 		"pythonversion",
@@ -470,6 +481,10 @@ func TestQuestionStructureValidity(t *testing.T) {
 				t.Error("Question name should not be empty")
 			}
 
+			if q.Label == "" {
+				t.Error("Question label should not be empty")
+			}
+
 			if q.Question != nil && q.Question.Prompt == nil {
 				t.Error("Question prompt should not be nil")
 			}
@@ -546,4 +561,291 @@ func TestCustomPathLogic(t *testing.T) {
 	if expectedPath != expectedFull {
 		t.Errorf("Expected path %s, got %s", expectedFull, expectedPath)
 	}
-}
\ No newline at end of file
+}
+
+// Test that non-interactive configs get the prompt defaults
+func TestApplyDefaults(t *testing.T) {
+	cfg := &config.ProjectConfig{ProjectType: "web"}
+	ApplyDefaults(cfg)
+
+	if cfg.WebFramework != "fastapi" {
+		t.Errorf("WebFramework = %q, want %q", cfg.WebFramework, "fastapi")
+	}
+	if cfg.PythonVersion != "3.13" {
+		t.Errorf("PythonVersion = %q, want %q", cfg.PythonVersion, "3.13")
+	}
+	if cfg.ProjectDescription != "A Python project" {
+		t.Errorf("ProjectDescription = %q, want %q", cfg.ProjectDescription, "A Python project")
+	}
+
+	basicCfg := &config.ProjectConfig{}
+	ApplyDefaults(basicCfg)
+
+	if basicCfg.ProjectType != "basic" {
+		t.Errorf("ProjectType = %q, want %q", basicCfg.ProjectType, "basic")
+	}
+	if basicCfg.WebFramework != "" {
+		t.Errorf("WebFramework should stay empty for basic projects, got %q", basicCfg.WebFramework)
+	}
+}
+
+// Test that the main directory defaults to a package name derived from the project name
+func TestDefaultMainDirName(t *testing.T) {
+	tests := map[string]string{
+		"My Project":  "my_project",
+		"Ünïcode App": "unicode_app",
+		"2048 Game":   "_2048_game",
+		"JSON":        "json_",
+		"class":       "class_",
+		"日本":          "",
+	}
+
+	for projectName, want := range tests {
+		cfg := &config.ProjectConfig{ProjectName: projectName}
+		if got := defaultMainDirName(cfg); got != want {
+			t.Errorf("defaultMainDirName(%q) = %q, want %q", projectName, got, want)
+		}
+
+		ApplyDefaults(cfg)
+		if cfg.MainDirName != want {
+			t.Errorf("ApplyDefaults set MainDirName = %q for %q, want %q", cfg.MainDirName, projectName, want)
+		}
+	}
+
+	// An explicit main directory is kept
+	cfg := &config.ProjectConfig{ProjectName: "My Project", MainDirName: "core"}
+	ApplyDefaults(cfg)
+	if cfg.MainDirName != "core" {
+		t.Errorf("MainDirName = %q, want the explicit %q", cfg.MainDirName, "core")
+	}
+}
+
+func TestUserDefaults(t *testing.T) {
+	gitConfigFile := filepath.Join(t.TempDir(), "gitconfig")
+	if err := os.WriteFile(gitConfigFile, []byte("[user]\n\tname = Git User\n\temail = git@example.com\n"), 0644); err != nil {
+		t.Fatalf("Failed to write git config: %v", err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", gitConfigFile)
+
+	settings := ui.DefaultConfig()
+	settings.Defaults.Email = "jane@example.com"
+	settings.Defaults.PythonVersion = "3.12"
+
+	defaults := UserDefaults(settings)
+	want := map[string]string{
+		"username":      "Git User",
+		"email":         "jane@example.com",
+		"pythonversion": "3.12",
+	}
+	if !reflect.DeepEqual(defaults, want) {
+		t.Errorf("UserDefaults() = %v, want %v", defaults, want)
+	}
+
+	if err := ValidateSetting("defaults.python_version", "3.12.1"); err == nil {
+		t.Error("Expected a patch version to be rejected as the default Python version")
+	}
+	if err := ValidateSetting("ui.show_banner", "true"); err != nil {
+		t.Errorf("Settings that pre-fill no prompt should not be checked, got: %v", err)
+	}
+}
+
+// Test that ValidateConfig runs the same checks as the prompts
+func TestValidateConfig(t *testing.T) {
+	valid := func() *config.ProjectConfig {
+		return &config.ProjectConfig{
+			UserName:           "Test User",
+			Email:              "test@example.com",
+			ProjectName:        "Test Project",
+			ProjectType:        "basic",
+			MainDirName:        "test_project",
+			ProjectDescription: "A test project",
+			PythonVersion:      "3.12",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(*config.ProjectConfig)
+		questionID string
+	}{
+		{name: "valid config", mutate: func(c *config.ProjectConfig) {}},
+		{name: "missing user name", mutate: func(c *config.ProjectConfig) { c.UserName = "" }, questionID: "username"},
+		{name: "invalid email", mutate: func(c *config.ProjectConfig) { c.Email = "nope" }, questionID: "email"},
+		{name: "unknown project type", mutate: func(c *config.ProjectConfig) { c.ProjectType = "rocket" }, questionID: "projecttype"},
+		{name: "web without framework", mutate: func(c *config.ProjectConfig) { c.ProjectType = "web" }, questionID: "webframework"},
+		{name: "missing main dir", mutate: func(c *config.ProjectConfig) { c.MainDirName = "" }, questionID: "maindirname"},
+		{name: "unicode project name", mutate: func(c *config.ProjectConfig) { c.ProjectName = "Ünïcode App" }, questionID: "projectname"},
+		{name: "main dir with dash", mutate: func(c *config.ProjectConfig) { c.MainDirName = "my-pkg" }, questionID: "maindirname"},
+		{name: "main dir keyword", mutate: func(c *config.ProjectConfig) { c.MainDirName = "class" }, questionID: "maindirname"},
+		{name: "main dir shadows stdlib", mutate: func(c *config.ProjectConfig) { c.MainDirName = "json" }, questionID: "maindirname"},
+		{name: "patch python version", mutate: func(c *config.ProjectConfig) { c.PythonVersion = "3.12.1" }, questionID: "pythonversion"},
+		{name: "unsupported python version", mutate: func(c *config.ProjectConfig) { c.PythonVersion = "3.8" }, questionID: "pythonversion"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(cfg)
+
+			err := ValidateConfig(cfg)
+			if tt.questionID == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got: %v", err)
+			}
+			if validationErr.QuestionID != tt.questionID {
+				t.Errorf("QuestionID = %q, want %q", validationErr.QuestionID, tt.questionID)
+			}
+		})
+	}
+}
+
+// Test loading answers files in every supported format
+func TestLoadAnswers(t *testing.T) {
+	files := map[string]string{
+		"answers.yaml": `username: Jane Doe
+email: jane@example.com
+projecttype: web
+webframework: fastapi
+pythonversion: "3.12"
+dependencies:
+  - fastapi
+  - httpx
+setupenv: false
+`,
+		"answers.json": `{"username": "Jane Doe", "email": "jane@example.com", "projecttype": "web",
+"webframework": "fastapi", "pythonversion": "3.12", "dependencies": ["fastapi", "httpx"], "setupenv": false}`,
+		"answers.toml": `username = "Jane Doe"
+email = "jane@example.com"
+projecttype = "web"
+webframework = "fastapi"
+pythonversion = "3.12"
+dependencies = ["fastapi", "httpx"]
+setupenv = false
+`,
+	}
+
+	tempDir := t.TempDir()
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(tempDir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write answers file: %v", err)
+			}
+
+			answers, err := LoadAnswers(path)
+			if err != nil {
+				t.Fatalf("LoadAnswers failed: %v", err)
+			}
+
+			if value, ok := answers.Value("username"); !ok || value != "Jane Doe" {
+				t.Errorf("username = %q (present %v), want %q", value, ok, "Jane Doe")
+			}
+			if value, ok := answers.Value("pythonversion"); !ok || value != "3.12" {
+				t.Errorf("pythonversion = %q (present %v), want %q", value, ok, "3.12")
+			}
+			if _, ok := answers.Value("projectname"); ok {
+				t.Error("projectname should be missing so it is prompted for")
+			}
+
+			deps, ok := answers.Dependencies()
+			if !ok || len(deps) != 2 || deps[0] != "fastapi" || deps[1] != "httpx" {
+				t.Errorf("Dependencies() = %v (present %v), want [fastapi httpx]", deps, ok)
+			}
+
+			setupEnv, ok := answers.SetupEnvironment()
+			if !ok || setupEnv {
+				t.Errorf("SetupEnvironment() = %v (present %v), want false", setupEnv, ok)
+			}
+		})
+	}
+}
+
+// Test that invalid answers are rejected
+func TestParseAnswersErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       map[string]interface{}
+		errorText string
+	}{
+		{
+			name:      "unknown key",
+			raw:       map[string]interface{}{"favouritecolour": "blue"},
+			errorText: "unknown question ID: favouritecolour",
+		},
+		{
+			name:      "unquoted version",
+			raw:       map[string]interface{}{"pythonversion": 3.1},
+			errorText: "must be a string",
+		},
+		{
+			name:      "dependencies not a list",
+			raw:       map[string]interface{}{"dependencies": "fastapi"},
+			errorText: "list of strings",
+		},
+		{
+			name:      "setupenv not a bool",
+			raw:       map[string]interface{}{"setupenv": "yes"},
+			errorText: "true or false",
+		},
+		{
+			name:      "pack answers not a map",
+			raw:       map[string]interface{}{"answers": "payments"},
+			errorText: "must map question IDs to answers",
+		},
+		{
+			name:      "registerkernel not a bool",
+			raw:       map[string]interface{}{"registerkernel": "yes"},
+			errorText: "true or false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAnswers(tt.raw)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorText, err.Error())
+			}
+		})
+	}
+}
+
+// Test that a complete answers file needs no prompts at all
+func TestCollectAllDetailsFromAnswers(t *testing.T) {
+	answers, err := ParseAnswers(map[string]interface{}{
+		"username":      "Jane Doe",
+		"email":         "jane@example.com",
+		"projectname":   "Answered Project",
+		"projecttype":   "web",
+		"webframework":  "fastapi",
+		"maindirname":   "answered_project",
+		"description":   "From a file",
+		"pythonversion": "3.12",
+	})
+	if err != nil {
+		t.Fatalf("ParseAnswers failed: %v", err)
+	}
+
+	cfg := &config.ProjectConfig{}
+	if err := collectAllDetails(cfg, answers, nil); err != nil {
+		t.Fatalf("collectAllDetails failed: %v", err)
+	}
+
+	if cfg.WebFramework != "fastapi" || cfg.MainDirName != "answered_project" || cfg.PythonVersion != "3.12" {
+		t.Errorf("Config not filled from answers: %+v", cfg)
+	}
+
+	invalid, _ := ParseAnswers(map[string]interface{}{"username": "Jane", "email": "not-an-email"})
+	if err := collectAllDetails(&config.ProjectConfig{}, invalid, nil); err == nil {
+		t.Error("Expected invalid email answer to be rejected")
+	}
+}
@@ -0,0 +1,322 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/pkg/template"
+)
+
+// questionIDPattern restricts pack question IDs to names templates can use as answers.<id>
+var questionIDPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// ValidateQuestions checks the questions a template pack declares for a kind
+func ValidateQuestions(questions []config.Question) error {
+	seen := map[string]bool{}
+	for _, q := range questions {
+		if !questionIDPattern.MatchString(q.ID) {
+			return fmt.Errorf("question id %q must be lowercase letters, digits and underscores", q.ID)
+		}
+		if seen[q.ID] {
+			return fmt.Errorf("question %s is listed twice", q.ID)
+		}
+		seen[q.ID] = true
+
+		if q.Message == "" {
+			return fmt.Errorf("question %s: message is required", q.ID)
+		}
+
+		switch q.Kind() {
+		case config.QuestionInput, config.QuestionConfirm:
+		case config.QuestionSelect, config.QuestionMultiSelect:
+			if len(q.Choices) == 0 {
+				return fmt.Errorf("question %s: %s questions need choices", q.ID, q.Kind())
+			}
+		default:
+			return fmt.Errorf("question %s: unknown type %q (use input, select, multiselect or confirm)", q.ID, q.Type)
+		}
+
+		if q.Pattern != "" {
+			if q.Kind() != config.QuestionInput {
+				return fmt.Errorf("question %s: only input questions can have a pattern", q.ID)
+			}
+			if _, err := regexp.Compile(q.Pattern); err != nil {
+				return fmt.Errorf("question %s: invalid pattern: %w", q.ID, err)
+			}
+		}
+
+		if q.When != "" {
+			if _, err := template.NewEngine().RenderString(conditionTemplate(q.When), nil); err != nil {
+				return fmt.Errorf("question %s: invalid condition: %w", q.ID, err)
+			}
+		}
+
+		if q.Default != nil {
+			def, err := ParseAnswer(q, q.Default)
+			if err == nil && !isEmptyAnswer(def) {
+				err = validateQuestionAnswer(q, def)
+			}
+			if err != nil {
+				return fmt.Errorf("question %s: invalid default: %w", q.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// kindQuestions returns the questions declared for the kind cfg selects, if any
+func kindQuestions(cfg *config.ProjectConfig) []config.Question {
+	kind, err := cfg.Kind()
+	if err != nil {
+		return nil
+	}
+	return kind.Questions
+}
+
+// ParseAnswer converts a recorded answer to the type of answer q takes. Strings, as
+// given on the command line, are accepted for every type: "true" or "false" for confirm
+// questions and comma-separated choices for multiselect questions.
+func ParseAnswer(q config.Question, value interface{}) (interface{}, error) {
+	switch q.Kind() {
+	case config.QuestionConfirm:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("must be true or false")
+			}
+			return b, nil
+		}
+		return nil, fmt.Errorf("must be true or false")
+	case config.QuestionMultiSelect:
+		if str, ok := value.(string); ok {
+			items := []string{}
+			for _, item := range strings.Split(str, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items, nil
+		}
+		if list, ok := value.([]string); ok {
+			return list, nil
+		}
+		return stringList(value)
+	default:
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a string (quote values such as versions)")
+		}
+		return str, nil
+	}
+}
+
+// ApplyAnswers records answers to the questions of the kind cfg selects, converting
+// each with ParseAnswer. Answers to questions the kind does not declare are an error.
+func ApplyAnswers(cfg *config.ProjectConfig, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	questions := map[string]config.Question{}
+	for _, q := range kindQuestions(cfg) {
+		questions[q.ID] = q
+	}
+
+	for id, value := range values {
+		q, ok := questions[id]
+		if !ok {
+			return &ValidationError{QuestionID: id, Err: fmt.Errorf("not a question of %s projects", cfg.ProjectType)}
+		}
+		answer, err := ParseAnswer(q, value)
+		if err != nil {
+			return &ValidationError{QuestionID: id, Err: err}
+		}
+		if cfg.Answers == nil {
+			cfg.Answers = map[string]interface{}{}
+		}
+		cfg.Answers[id] = answer
+	}
+
+	return nil
+}
+
+// collectKindAnswers asks the questions the selected kind declares, skipping those the
+// answers file recorded and those whose condition is false
+func collectKindAnswers(cfg *config.ProjectConfig, answers *Answers) error {
+	questions := kindQuestions(cfg)
+	if len(questions) == 0 {
+		return nil
+	}
+
+	if err := ApplyAnswers(cfg, answers.PackAnswers()); err != nil {
+		return err
+	}
+
+	for _, q := range questions {
+		applies, err := questionApplies(q, cfg)
+		if err != nil {
+			return err
+		}
+		if !applies {
+			delete(cfg.Answers, q.ID)
+			continue
+		}
+
+		// Use the recorded answer instead of prompting, if there is one
+		if answer, ok := cfg.Answers[q.ID]; ok {
+			if err := validateQuestionAnswer(q, answer); err != nil {
+				return &ValidationError{QuestionID: q.ID, Err: err}
+			}
+			continue
+		}
+
+		answer, err := askQuestion(q, questionDefault(q))
+		if err != nil {
+			return fmt.Errorf("failed to collect %s: %w", q.ID, err)
+		}
+		if cfg.Answers == nil {
+			cfg.Answers = map[string]interface{}{}
+		}
+		cfg.Answers[q.ID] = answer
+	}
+
+	return nil
+}
+
+// askQuestion prompts for the answer to a pack question, offering def as the default
+func askQuestion(q config.Question, def interface{}) (interface{}, error) {
+	validate := func(ans interface{}) error {
+		value, err := ParseAnswer(q, surveyAnswer(ans))
+		if err != nil {
+			return err
+		}
+		return validateQuestionAnswer(q, value)
+	}
+
+	switch q.Kind() {
+	case config.QuestionConfirm:
+		confirmDefault, _ := def.(bool)
+		answer := false
+		err := survey.AskOne(&survey.Confirm{Message: q.Message, Help: q.Help, Default: confirmDefault}, &answer)
+		return answer, err
+	case config.QuestionMultiSelect:
+		selected, _ := def.([]string)
+		var answer []string
+		prompt := &survey.MultiSelect{Message: q.Message, Help: q.Help, Options: q.Choices, Default: selected}
+		err := survey.AskOne(prompt, &answer, survey.WithValidator(validate))
+		if answer == nil {
+			answer = []string{}
+		}
+		return answer, err
+	case config.QuestionSelect:
+		prompt := &survey.Select{Message: q.Message, Help: q.Help, Options: q.Choices}
+		if choice, _ := def.(string); choice != "" {
+			prompt.Default = choice
+		}
+		var answer string
+		err := survey.AskOne(prompt, &answer)
+		return answer, err
+	default:
+		text, _ := def.(string)
+		var answer string
+		err := survey.AskOne(&survey.Input{Message: q.Message, Help: q.Help, Default: text}, &answer, survey.WithValidator(validate))
+		return answer, err
+	}
+}
+
+// surveyAnswer unwraps the option lists survey passes to multiselect validators
+func surveyAnswer(ans interface{}) interface{} {
+	options, ok := ans.([]survey.OptionAnswer)
+	if !ok {
+		return ans
+	}
+	values := make([]string, len(options))
+	for i, option := range options {
+		values[i] = option.Value
+	}
+	return values
+}
+
+// questionDefault returns the declared default converted to the question's answer type,
+// or nil when there is none
+func questionDefault(q config.Question) interface{} {
+	if q.Default == nil {
+		return nil
+	}
+	def, err := ParseAnswer(q, q.Default)
+	if err != nil {
+		return nil
+	}
+	return def
+}
+
+// questionApplies evaluates the question's condition against the answers so far
+func questionApplies(q config.Question, cfg *config.ProjectConfig) (bool, error) {
+	if q.When == "" {
+		return true, nil
+	}
+
+	result, err := template.NewEngine().RenderString(conditionTemplate(q.When), cfg.TemplateContext())
+	if err != nil {
+		return false, fmt.Errorf("invalid condition %q for question %s: %w", q.When, q.ID, err)
+	}
+	return strings.TrimSpace(result) == "true", nil
+}
+
+// conditionTemplate wraps a condition in a template that renders "true" when it holds
+func conditionTemplate(condition string) string {
+	return "{% if " + condition + " %}true{% endif %}"
+}
+
+// validateQuestionAnswer checks an answer of the question's type the way the prompt would
+func validateQuestionAnswer(q config.Question, answer interface{}) error {
+	if isEmptyAnswer(answer) {
+		if q.Required {
+			return ErrRequired
+		}
+		return nil
+	}
+
+	switch value := answer.(type) {
+	case string:
+		if q.Pattern != "" && !regexp.MustCompile(q.Pattern).MatchString(value) {
+			return fmt.Errorf("%q does not match %s", value, q.Pattern)
+		}
+		if len(q.Choices) > 0 && !slices.Contains(q.Choices, value) {
+			return fmt.Errorf("%q is not one of: %s", value, strings.Join(q.Choices, ", "))
+		}
+	case []string:
+		for _, item := range value {
+			if !slices.Contains(q.Choices, item) {
+				return fmt.Errorf("%q is not one of: %s", item, strings.Join(q.Choices, ", "))
+			}
+		}
+	case bool:
+	default:
+		return errors.New("unexpected answer type")
+	}
+
+	return nil
+}
+
+// isEmptyAnswer reports whether an answer is an empty string or list. Confirm answers
+// are never empty.
+func isEmptyAnswer(answer interface{}) bool {
+	switch value := answer.(type) {
+	case string:
+		return strings.TrimSpace(value) == ""
+	case []string:
+		return len(value) == 0
+	}
+	return answer == nil
+}
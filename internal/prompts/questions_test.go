@@ -0,0 +1,219 @@
+package prompts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+)
+
+// serviceQuestions are the questions of the test pack kind registered by useServiceKind
+var serviceQuestions = []config.Question{
+	{ID: "team_name", Message: "Owning team", Required: true, Pattern: `^[a-z][a-z0-9-]*$`},
+	{ID: "oncall_channel", Message: "On-call channel", Default: "#oncall"},
+	{ID: "tier", Type: config.QuestionSelect, Message: "Deployment tier", Choices: []string{"dev", "staging", "production"}},
+	{ID: "regions", Type: config.QuestionMultiSelect, Message: "Regions", Choices: []string{"eu", "us"}, Default: []interface{}{"eu"}},
+	{ID: "paging", Type: config.QuestionConfirm, Message: "Page on-call?", Default: true, When: `answers.tier == "production"`},
+}
+
+// useServiceKind registers a template pack kind that declares serviceQuestions
+func useServiceKind(t *testing.T) {
+	t.Helper()
+
+	kind := config.ProjectKind{Type: "acme-service", Implemented: true, Pack: "acme-questions", Questions: serviceQuestions}
+	if err := config.RegisterKind(kind); err != nil {
+		t.Fatalf("RegisterKind failed: %v", err)
+	}
+	t.Cleanup(func() { config.UnregisterPack("acme-questions") })
+}
+
+func TestValidateQuestions(t *testing.T) {
+	if err := ValidateQuestions(serviceQuestions); err != nil {
+		t.Fatalf("ValidateQuestions() error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		question  config.Question
+		errorText string
+	}{
+		{"bad id", config.Question{ID: "Team Name", Message: "Team"}, "must be lowercase"},
+		{"no message", config.Question{ID: "team"}, "message is required"},
+		{"unknown type", config.Question{ID: "team", Message: "Team", Type: "slider"}, "unknown type"},
+		{"select without choices", config.Question{ID: "tier", Message: "Tier", Type: "select"}, "need choices"},
+		{"pattern on confirm", config.Question{ID: "ok", Message: "OK?", Type: "confirm", Pattern: "x"}, "only input questions"},
+		{"invalid pattern", config.Question{ID: "team", Message: "Team", Pattern: "("}, "invalid pattern"},
+		{"invalid condition", config.Question{ID: "team", Message: "Team", When: "answers.tier =="}, "invalid condition"},
+		{"default not a choice", config.Question{ID: "tier", Message: "Tier", Type: "select", Choices: []string{"dev"}, Default: "prod"}, "invalid default"},
+		{"confirm default not a bool", config.Question{ID: "ok", Message: "OK?", Type: "confirm", Default: "maybe"}, "invalid default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuestions([]config.Question{tt.question})
+			if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("ValidateQuestions() error = %v, want error containing %q", err, tt.errorText)
+			}
+		})
+	}
+
+	duplicate := []config.Question{{ID: "team", Message: "Team"}, {ID: "team", Message: "Team"}}
+	if err := ValidateQuestions(duplicate); err == nil || !strings.Contains(err.Error(), "listed twice") {
+		t.Errorf("ValidateQuestions() error = %v, want duplicate reported", err)
+	}
+}
+
+func TestParseAnswer(t *testing.T) {
+	confirm := config.Question{ID: "ok", Type: config.QuestionConfirm}
+	multi := config.Question{ID: "regions", Type: config.QuestionMultiSelect}
+	input := config.Question{ID: "team"}
+
+	tests := []struct {
+		question config.Question
+		value    interface{}
+		want     interface{}
+	}{
+		{confirm, true, true},
+		{confirm, "false", false},
+		{multi, "eu, us", []string{"eu", "us"}},
+		{multi, "", []string{}},
+		{multi, []interface{}{"eu"}, []string{"eu"}},
+		{input, "payments", "payments"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAnswer(tt.question, tt.value)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseAnswer(%s, %#v) = %#v, %v; want %#v", tt.question.ID, tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []struct {
+		question config.Question
+		value    interface{}
+	}{{confirm, "maybe"}, {multi, 3}, {input, 3.12}} {
+		if _, err := ParseAnswer(bad.question, bad.value); err == nil {
+			t.Errorf("ParseAnswer(%s, %#v) succeeded, want error", bad.question.ID, bad.value)
+		}
+	}
+}
+
+func TestPackQuestionsNonInteractive(t *testing.T) {
+	useServiceKind(t)
+
+	newConfig := func() *config.ProjectConfig {
+		return &config.ProjectConfig{
+			UserName:    "Test User",
+			Email:       "test@example.com",
+			ProjectName: "Billing",
+			ProjectType: "acme-service",
+			MainDirName: "billing",
+		}
+	}
+
+	cfg := newConfig()
+	if err := ApplyAnswers(cfg, map[string]interface{}{"team_name": "payments", "tier": "production"}); err != nil {
+		t.Fatalf("ApplyAnswers failed: %v", err)
+	}
+	ApplyDefaults(cfg)
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("ValidateConfig failed: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"team_name":      "payments",
+		"oncall_channel": "#oncall",
+		"tier":           "production",
+		"regions":        []string{"eu"},
+		"paging":         true,
+	}
+	if !reflect.DeepEqual(cfg.Answers, want) {
+		t.Errorf("Answers = %#v, want %#v", cfg.Answers, want)
+	}
+
+	// Answers land in the template context
+	context := cfg.TemplateContext()
+	if answers := context["answers"].(map[string]interface{}); answers["team_name"] != "payments" {
+		t.Errorf("TemplateContext()[\"answers\"] = %v, want the team name", answers)
+	}
+
+	// The paging question only applies to production services
+	cfg = newConfig()
+	_ = ApplyAnswers(cfg, map[string]interface{}{"team_name": "payments"})
+	ApplyDefaults(cfg)
+	if _, ok := cfg.Answers["paging"]; ok || cfg.Answers["tier"] != "dev" {
+		t.Errorf("Answers = %#v, want the first tier and no paging answer", cfg.Answers)
+	}
+
+	tests := []struct {
+		name       string
+		answers    map[string]interface{}
+		questionID string
+		applyErr   bool
+	}{
+		{name: "missing required answer", answers: nil, questionID: "team_name"},
+		{name: "pattern mismatch", answers: map[string]interface{}{"team_name": "Payments Team"}, questionID: "team_name"},
+		{name: "not a choice", answers: map[string]interface{}{"team_name": "payments", "tier": "qa"}, questionID: "tier"},
+		{name: "unknown question", answers: map[string]interface{}{"colour": "blue"}, questionID: "colour", applyErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			err := ApplyAnswers(cfg, tt.answers)
+			if !tt.applyErr {
+				if err != nil {
+					t.Fatalf("ApplyAnswers failed: %v", err)
+				}
+				ApplyDefaults(cfg)
+				err = ValidateConfig(cfg)
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.QuestionID != tt.questionID {
+				t.Errorf("error = %v, want a ValidationError for %s", err, tt.questionID)
+			}
+		})
+	}
+}
+
+// Test that recorded answers to pack questions need no prompts
+func TestCollectKindAnswersFromAnswers(t *testing.T) {
+	useServiceKind(t)
+
+	answers, err := ParseAnswers(map[string]interface{}{
+		"answers": map[string]interface{}{
+			"team_name":      "payments",
+			"oncall_channel": "#payments-oncall",
+			"tier":           "dev",
+			"regions":        []interface{}{"eu", "us"},
+			"paging":         true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseAnswers failed: %v", err)
+	}
+
+	cfg := &config.ProjectConfig{ProjectType: "acme-service"}
+	if err := collectKindAnswers(cfg, answers); err != nil {
+		t.Fatalf("collectKindAnswers failed: %v", err)
+	}
+
+	// paging does not apply to dev services, so its recorded answer is dropped
+	want := map[string]interface{}{
+		"team_name":      "payments",
+		"oncall_channel": "#payments-oncall",
+		"tier":           "dev",
+		"regions":        []string{"eu", "us"},
+	}
+	if !reflect.DeepEqual(cfg.Answers, want) {
+		t.Errorf("Answers = %#v, want %#v", cfg.Answers, want)
+	}
+
+	invalid, _ := ParseAnswers(map[string]interface{}{"answers": map[string]interface{}{"team_name": "Payments", "oncall_channel": "x", "tier": "dev", "regions": []interface{}{}}})
+	if err := collectKindAnswers(&config.ProjectConfig{ProjectType: "acme-service"}, invalid); err == nil {
+		t.Error("Expected the team name not matching the pattern to be rejected")
+	}
+}
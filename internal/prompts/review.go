@@ -0,0 +1,292 @@
+package prompts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/Pradyothsp/pyinit/internal/config"
+)
+
+// Choices on the review screen besides editing a single answer
+const (
+	reviewCreate   = "Create the project"
+	reviewLocation = "Change location"
+	reviewEdit     = "Edit "
+)
+
+// reviewField is one answer on the review screen
+type reviewField struct {
+	label string
+	value string
+	edit  func(cfg *config.ProjectConfig) error
+}
+
+// Choice is a setup question asked after the project questions, such as whether to set up
+// the environment, that the review screen shows and lets the user change
+type Choice struct {
+	Label string
+
+	// Scope names what the choice is about for cfg, such as the framework whose
+	// dependencies are offered, and is empty when the choice does not apply. An edit that
+	// changes the scope clears the choice and asks it again.
+	Scope func(cfg *config.ProjectConfig) string
+
+	Value func(cfg *config.ProjectConfig) interface{} // The answer, formatted like a pack answer
+	Ask   func(cfg *config.ProjectConfig) error       // Prompts for the choice and records it in cfg
+	Clear func(cfg *config.ProjectConfig)             // Resets the choice when it no longer applies
+}
+
+// ReviewProjectInfo shows every answer, setup choice and the project path, and lets the
+// user re-answer any single one until they confirm generation. Re-asked questions offer
+// the answers in defaults, as CollectProjectInfo does. Nothing is shown when answers
+// records the location, as the run then needs no input.
+func ReviewProjectInfo(cfg *config.ProjectConfig, answers *Answers, defaults map[string]string, choices []Choice) error {
+	if _, ok := answers.Value(ParentDirAnswerID); ok {
+		return nil
+	}
+
+	for {
+		fields := reviewFields(cfg, defaults, choices)
+
+		fmt.Println()
+		printReview(os.Stdout, cfg, fields)
+
+		options := []string{reviewCreate, reviewLocation}
+		for _, field := range fields {
+			options = append(options, reviewEdit+field.label)
+		}
+
+		var choice string
+		prompt := &survey.Select{
+			Message:  "Create the project, or change an answer?",
+			Options:  options,
+			Default:  reviewCreate,
+			PageSize: len(options),
+		}
+		if err := survey.AskOne(prompt, &choice); err != nil {
+			return fmt.Errorf("failed to get review choice: %w", err)
+		}
+
+		switch choice {
+		case reviewCreate:
+			return nil
+		case reviewLocation:
+			if err := askForCustomPath(cfg); err != nil {
+				return fmt.Errorf("failed to get custom path: %w", err)
+			}
+		default:
+			for _, field := range fields {
+				if choice != reviewEdit+field.label {
+					continue
+				}
+				scopes := choiceScopes(cfg, choices)
+				if err := field.edit(cfg); err != nil {
+					return err
+				}
+				if err := refreshChoices(cfg, choices, scopes); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// printReview prints fields as a table, followed by the path of the project cfg describes
+func printReview(w io.Writer, cfg *config.ProjectConfig, fields []reviewField) {
+	fmt.Fprintln(w, "📋 Review your project:")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "  %s\t%s\n", field.label, field.value)
+	}
+	fmt.Fprintf(tw, "  %s\t%s\n", "Location", cfg.ProjectPath)
+	_ = tw.Flush()
+
+	fmt.Fprintln(w)
+}
+
+// reviewFields lists the answers that apply to cfg in the order they are asked: the
+// question flow first, then the questions of the kind's template pack, then the choices
+func reviewFields(cfg *config.ProjectConfig, defaults map[string]string, choices []Choice) []reviewField {
+	var fields []reviewField
+	for _, step := range buildCompleteQuestionFlow() {
+		if step.Condition != nil && !step.Condition(cfg) {
+			continue
+		}
+		id := step.ID
+		fields = append(fields, reviewField{
+			label: step.Label,
+			value: stepPreview(cfg, id),
+			edit:  func(cfg *config.ProjectConfig) error { return reanswerStep(cfg, id, defaults) },
+		})
+	}
+
+	for _, q := range kindQuestions(cfg) {
+		if _, ok := cfg.Answers[q.ID]; !ok {
+			continue
+		}
+		fields = append(fields, reviewField{
+			label: q.ID,
+			value: formatAnswer(cfg.Answers[q.ID]),
+			edit:  func(cfg *config.ProjectConfig) error { return reanswerQuestion(cfg, q) },
+		})
+	}
+
+	for _, choice := range choices {
+		if choice.Scope(cfg) == "" {
+			continue
+		}
+		fields = append(fields, reviewField{
+			label: choice.Label,
+			value: formatAnswer(choice.Value(cfg)),
+			edit:  choice.Ask,
+		})
+	}
+
+	return fields
+}
+
+// stepPreview formats the answer to a flow question for the review table, showing the
+// import statement the package name gives
+func stepPreview(cfg *config.ProjectConfig, stepID string) string {
+	answer := answerFromConfig(cfg, stepID)
+	if stepID == "maindirname" && answer != "" {
+		return fmt.Sprintf("%s (import %s)", answer, answer)
+	}
+	return answer
+}
+
+// choiceScopes records the scope of each choice, to find the ones an edit changes
+func choiceScopes(cfg *config.ProjectConfig, choices []Choice) []string {
+	scopes := make([]string, len(choices))
+	for i, choice := range choices {
+		scopes[i] = choice.Scope(cfg)
+	}
+	return scopes
+}
+
+// refreshChoices clears the choices whose scope changed from before, such as the
+// dependencies after the framework changes, and asks again the ones that still apply
+func refreshChoices(cfg *config.ProjectConfig, choices []Choice, before []string) error {
+	for i, choice := range choices {
+		scope := choice.Scope(cfg)
+		if scope == before[i] {
+			continue
+		}
+		choice.Clear(cfg)
+		if scope == "" {
+			continue
+		}
+		if err := choice.Ask(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatAnswer formats a pack question's answer for the review table
+func formatAnswer(answer interface{}) string {
+	switch value := answer.(type) {
+	case bool:
+		if value {
+			return "yes"
+		}
+		return "no"
+	case []string:
+		if len(value) == 0 {
+			return "none"
+		}
+		return strings.Join(value, ", ")
+	}
+	return fmt.Sprint(answer)
+}
+
+// reanswerStep asks a question of the flow again, then asks the questions the new answer
+// makes apply, such as the web framework when the type changes to web, offering the
+// answers in defaults for those
+func reanswerStep(cfg *config.ProjectConfig, stepID string, defaults map[string]string) error {
+	for _, step := range buildCompleteQuestionFlow() {
+		if step.ID != stepID {
+			continue
+		}
+
+		answer, err := askStep(step, answerFromConfig(cfg, step.ID))
+		if err != nil {
+			return err
+		}
+
+		for _, missing := range applyEdit(cfg, step.ID, answer) {
+			answer, err := askStep(missing, offeredDefault(missing, cfg, defaults))
+			if err != nil {
+				return err
+			}
+			if err := updateConfigFromAnswer(cfg, missing.ID, answer); err != nil {
+				return fmt.Errorf("failed to process %s: %w", missing.ID, err)
+			}
+		}
+	}
+
+	return refreshKindAnswers(cfg)
+}
+
+// applyEdit records a changed answer and keeps the rest of cfg consistent with it: values
+// derived from the project name follow the new name, answers to questions that no longer
+// apply are cleared, and the questions that now apply but have no answer are returned
+func applyEdit(cfg *config.ProjectConfig, stepID, answer string) []QuestionStep {
+	previous := *cfg
+	if err := updateConfigFromAnswer(cfg, stepID, answer); err != nil {
+		return nil
+	}
+
+	if stepID == "projectname" {
+		if cfg.MainDirName == defaultMainDirName(&previous) {
+			cfg.MainDirName = defaultMainDirName(cfg)
+		}
+		if cfg.ProjectPath != "" && filepath.Base(cfg.ProjectPath) == config.SanitizeProjectName(previous.ProjectName) {
+			cfg.ProjectPath = filepath.Join(filepath.Dir(cfg.ProjectPath), config.SanitizeProjectName(cfg.ProjectName))
+		}
+	}
+
+	var missing []QuestionStep
+	for _, step := range buildCompleteQuestionFlow() {
+		applies := step.Condition == nil || step.Condition(cfg)
+		current := answerFromConfig(cfg, step.ID)
+		switch {
+		case !applies && current != "":
+			_ = updateConfigFromAnswer(cfg, step.ID, "")
+		case applies && current == "":
+			missing = append(missing, step)
+		}
+	}
+	return missing
+}
+
+// reanswerQuestion asks a template pack question again, offering the current answer
+func reanswerQuestion(cfg *config.ProjectConfig, q config.Question) error {
+	answer, err := askQuestion(q, cfg.Answers[q.ID])
+	if err != nil {
+		return fmt.Errorf("failed to collect %s: %w", q.ID, err)
+	}
+	cfg.Answers[q.ID] = answer
+
+	return refreshKindAnswers(cfg)
+}
+
+// refreshKindAnswers drops answers to questions the selected kind no longer asks, after
+// an edit changed the kind or a question's condition, and asks the ones still missing
+func refreshKindAnswers(cfg *config.ProjectConfig) error {
+	kept := map[string]interface{}{}
+	for _, q := range kindQuestions(cfg) {
+		if answer, ok := cfg.Answers[q.ID]; ok {
+			kept[q.ID] = answer
+		}
+	}
+	cfg.Answers = kept
+
+	return collectKindAnswers(cfg, nil)
+}
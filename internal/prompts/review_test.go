@@ -0,0 +1,212 @@
+package prompts
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+)
+
+// reviewConfig returns a complete web project configuration
+func reviewConfig() *config.ProjectConfig {
+	return &config.ProjectConfig{
+		UserName:           "Jane Doe",
+		Email:              "jane@example.com",
+		ProjectName:        "My App",
+		ProjectType:        "web",
+		WebFramework:       "fastapi",
+		MainDirName:        "my_app",
+		ProjectDescription: "A Python project",
+		PythonVersion:      "3.13",
+		ProjectPath:        filepath.Join("/work", "my-app"),
+	}
+}
+
+// reviewChoices returns a dependency choice that applies to web projects, and a choice
+// that never applies
+func reviewChoices(asked *[]string) []Choice {
+	return []Choice{
+		{
+			Label: "Dependencies",
+			Scope: func(cfg *config.ProjectConfig) string {
+				if cfg.ProjectType != "web" {
+					return ""
+				}
+				return cfg.WebFramework
+			},
+			Value: func(cfg *config.ProjectConfig) interface{} { return cfg.Dependencies },
+			Ask: func(cfg *config.ProjectConfig) error {
+				*asked = append(*asked, "Dependencies")
+				cfg.Dependencies = []string{cfg.WebFramework}
+				return nil
+			},
+			Clear: func(cfg *config.ProjectConfig) { cfg.Dependencies = nil },
+		},
+		{
+			Label: "Jupyter kernel",
+			Scope: func(cfg *config.ProjectConfig) string { return "" },
+			Value: func(cfg *config.ProjectConfig) interface{} { return cfg.RegisterKernel },
+			Ask: func(cfg *config.ProjectConfig) error {
+				*asked = append(*asked, "Jupyter kernel")
+				return nil
+			},
+			Clear: func(cfg *config.ProjectConfig) { cfg.RegisterKernel = false },
+		},
+	}
+}
+
+func TestPrintReview(t *testing.T) {
+	cfg := reviewConfig()
+	cfg.Dependencies = []string{"fastapi", "uvicorn"}
+
+	var buf bytes.Buffer
+	printReview(&buf, cfg, reviewFields(cfg, nil, reviewChoices(new([]string))))
+	output := buf.String()
+
+	for _, want := range []string{
+		"  Author          Jane Doe\n",
+		"  Web framework   fastapi\n",
+		"  Package         my_app (import my_app)\n",
+		"  Python version  3.13\n",
+		"  Dependencies    fastapi, uvicorn\n",
+		"  Location        " + filepath.Join("/work", "my-app") + "\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Review is missing %q:\n%s", want, output)
+		}
+	}
+
+	// Questions that do not apply are not shown
+	if strings.Contains(output, "CLI framework") {
+		t.Errorf("Review shows the CLI framework of a web project:\n%s", output)
+	}
+	if strings.Contains(output, "Jupyter kernel") {
+		t.Errorf("Review shows a choice that does not apply:\n%s", output)
+	}
+}
+
+func TestRefreshChoices(t *testing.T) {
+	var asked []string
+	choices := reviewChoices(&asked)
+
+	t.Run("unchanged scope keeps the choice", func(t *testing.T) {
+		asked = nil
+		cfg := reviewConfig()
+		cfg.Dependencies = []string{"uvicorn"}
+
+		before := choiceScopes(cfg, choices)
+		cfg.ProjectDescription = "Another description"
+		if err := refreshChoices(cfg, choices, before); err != nil {
+			t.Fatalf("refreshChoices() error = %v", err)
+		}
+		if len(asked) != 0 || !reflect.DeepEqual(cfg.Dependencies, []string{"uvicorn"}) {
+			t.Errorf("Asked %v, Dependencies = %v; want nothing asked and uvicorn kept", asked, cfg.Dependencies)
+		}
+	})
+
+	t.Run("changed scope asks again", func(t *testing.T) {
+		asked = nil
+		cfg := reviewConfig()
+		cfg.Dependencies = []string{"uvicorn"}
+
+		before := choiceScopes(cfg, choices)
+		applyEdit(cfg, "webframework", "flask")
+		if err := refreshChoices(cfg, choices, before); err != nil {
+			t.Fatalf("refreshChoices() error = %v", err)
+		}
+		if !reflect.DeepEqual(asked, []string{"Dependencies"}) || !reflect.DeepEqual(cfg.Dependencies, []string{"flask"}) {
+			t.Errorf("Asked %v, Dependencies = %v; want Dependencies asked again for flask", asked, cfg.Dependencies)
+		}
+	})
+
+	t.Run("choice that stops applying is cleared", func(t *testing.T) {
+		asked = nil
+		cfg := reviewConfig()
+		cfg.Dependencies = []string{"uvicorn"}
+
+		before := choiceScopes(cfg, choices)
+		applyEdit(cfg, "projecttype", "basic")
+		if err := refreshChoices(cfg, choices, before); err != nil {
+			t.Fatalf("refreshChoices() error = %v", err)
+		}
+		if len(asked) != 0 || cfg.Dependencies != nil {
+			t.Errorf("Asked %v, Dependencies = %v; want nothing asked and the choice cleared", asked, cfg.Dependencies)
+		}
+	})
+}
+
+func TestOfferedDefault(t *testing.T) {
+	steps := map[string]QuestionStep{}
+	for _, step := range buildCompleteQuestionFlow() {
+		steps[step.ID] = step
+	}
+	cfg := reviewConfig()
+
+	tests := []struct {
+		stepID   string
+		defaults map[string]string
+		want     string
+	}{
+		{"username", map[string]string{"username": "Config User"}, "Config User"},
+		{"username", nil, ""},
+		{"maindirname", nil, "my_app"},
+		{"maindirname", map[string]string{"maindirname": "core"}, "core"},
+	}
+
+	for _, tt := range tests {
+		if got := offeredDefault(steps[tt.stepID], cfg, tt.defaults); got != tt.want {
+			t.Errorf("offeredDefault(%s, %v) = %q, want %q", tt.stepID, tt.defaults, got, tt.want)
+		}
+	}
+}
+
+func TestApplyEdit(t *testing.T) {
+	t.Run("type change clears and asks frameworks", func(t *testing.T) {
+		cfg := reviewConfig()
+
+		missing := applyEdit(cfg, "projecttype", "cli")
+		if cfg.WebFramework != "" {
+			t.Errorf("WebFramework = %q, want it cleared", cfg.WebFramework)
+		}
+		if len(missing) != 1 || missing[0].ID != "cliframework" {
+			t.Errorf("Missing questions = %v, want cliframework", stepIDs(missing))
+		}
+	})
+
+	t.Run("name change updates derived values", func(t *testing.T) {
+		cfg := reviewConfig()
+
+		if missing := applyEdit(cfg, "projectname", "Ünïcode App"); len(missing) != 0 {
+			t.Errorf("Missing questions = %v, want none", stepIDs(missing))
+		}
+		if cfg.MainDirName != "unicode_app" {
+			t.Errorf("MainDirName = %q, want it derived from the new name", cfg.MainDirName)
+		}
+		if cfg.ProjectPath != filepath.Join("/work", "unicode-app") {
+			t.Errorf("ProjectPath = %q, want the directory renamed", cfg.ProjectPath)
+		}
+	})
+
+	t.Run("name change keeps chosen values", func(t *testing.T) {
+		cfg := reviewConfig()
+		cfg.MainDirName = "core"
+		cfg.ProjectPath = filepath.Join("/work", "service")
+
+		applyEdit(cfg, "projectname", "Other App")
+		if cfg.MainDirName != "core" || cfg.ProjectPath != filepath.Join("/work", "service") {
+			t.Errorf("Chosen values changed: MainDirName = %q, ProjectPath = %q", cfg.MainDirName, cfg.ProjectPath)
+		}
+	})
+}
+
+// stepIDs lists the IDs of steps for test messages
+func stepIDs(steps []QuestionStep) []string {
+	ids := make([]string, len(steps))
+	for i, step := range steps {
+		ids[i] = step.ID
+	}
+	return ids
+}
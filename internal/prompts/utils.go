@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
 )
 
 func validateEmail(ans interface{}) error {
@@ -25,3 +27,14 @@ func validateEmail(ans interface{}) error {
 
 	return nil
 }
+
+// validateWith adapts a validation function to the validators survey questions take
+func validateWith(validate func(string) error) survey.Validator {
+	return func(ans interface{}) error {
+		str, ok := ans.(string)
+		if !ok {
+			return fmt.Errorf("invalid input")
+		}
+		return validate(str)
+	}
+}
@@ -0,0 +1,167 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/Pradyothsp/pyinit/internal/config"
+)
+
+// ErrRequired is reported when a required answer is empty
+var ErrRequired = errors.New("value is required")
+
+// ValidationError reports which question a non-interactive value failed on
+type ValidationError struct {
+	QuestionID string
+	Err        error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.QuestionID, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyDefaults fills empty config values with the defaults the prompts would offer
+func ApplyDefaults(cfg *config.ProjectConfig) {
+	for _, step := range buildCompleteQuestionFlow() {
+		if step.Condition != nil && !step.Condition(cfg) {
+			continue
+		}
+
+		if answerFromConfig(cfg, step.ID) != "" {
+			continue
+		}
+
+		if def := stepDefault(step, cfg); def != "" {
+			_ = updateConfigFromAnswer(cfg, step.ID, def)
+		}
+	}
+
+	// Pack questions default to their declared default, or the first choice of a select
+	for _, q := range kindQuestions(cfg) {
+		if _, ok := cfg.Answers[q.ID]; ok {
+			continue
+		}
+		if applies, err := questionApplies(q, cfg); err != nil || !applies {
+			continue
+		}
+
+		def := questionDefault(q)
+		if def == nil && q.Kind() == config.QuestionSelect {
+			def = q.Choices[0]
+		}
+		if def == nil {
+			continue
+		}
+		if cfg.Answers == nil {
+			cfg.Answers = map[string]interface{}{}
+		}
+		cfg.Answers[q.ID] = def
+	}
+}
+
+// ValidateConfig runs the same checks as the interactive prompts against an already populated config
+func ValidateConfig(cfg *config.ProjectConfig) error {
+	for _, step := range buildCompleteQuestionFlow() {
+		if step.Condition != nil && !step.Condition(cfg) {
+			continue
+		}
+
+		if err := validateAnswer(step, answerFromConfig(cfg, step.ID)); err != nil {
+			return &ValidationError{QuestionID: step.ID, Err: err}
+		}
+	}
+
+	for _, q := range kindQuestions(cfg) {
+		applies, err := questionApplies(q, cfg)
+		if err != nil {
+			return err
+		}
+		if !applies {
+			continue
+		}
+
+		answer, ok := cfg.Answers[q.ID]
+		if !ok {
+			answer = ""
+		}
+		if err := validateQuestionAnswer(q, answer); err != nil {
+			return &ValidationError{QuestionID: q.ID, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// validateAnswer checks a single answer the way the prompt for step would
+func validateAnswer(step QuestionStep, answer string) error {
+	if step.Required && strings.TrimSpace(answer) == "" {
+		return ErrRequired
+	}
+
+	if step.Question.Validate != nil {
+		if err := step.Question.Validate(answer); err != nil {
+			return err
+		}
+	}
+
+	if sel, ok := step.Question.Prompt.(*survey.Select); ok && !slices.Contains(sel.Options, answer) {
+		return fmt.Errorf("%q is not one of: %s", answer, strings.Join(sel.Options, ", "))
+	}
+
+	return nil
+}
+
+// answerFromConfig is the inverse of updateConfigFromAnswer
+func answerFromConfig(cfg *config.ProjectConfig, questionID string) string {
+	switch questionID {
+	case "username":
+		return cfg.UserName
+	case "email":
+		return cfg.Email
+	case "projectname":
+		return cfg.ProjectName
+	case "projecttype":
+		return cfg.ProjectType
+	case "webframework":
+		return cfg.WebFramework
+	case "cliframework":
+		return cfg.CLIFramework
+	case "maindirname":
+		return cfg.MainDirName
+	case "description":
+		return cfg.ProjectDescription
+	case "pythonversion":
+		return cfg.PythonVersion
+	}
+
+	return ""
+}
+
+// stepDefault returns the answer the prompt for step would offer by default
+func stepDefault(step QuestionStep, cfg *config.ProjectConfig) string {
+	if step.Default != nil {
+		return step.Default(cfg)
+	}
+	return promptDefault(step.Question.Prompt)
+}
+
+// promptDefault extracts the default answer from a survey prompt, if it has one
+func promptDefault(prompt survey.Prompt) string {
+	switch p := prompt.(type) {
+	case *survey.Input:
+		return p.Default
+	case *survey.Select:
+		if def, ok := p.Default.(string); ok {
+			return def
+		}
+	}
+
+	return ""
+}
@@ -4,20 +4,35 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
 )
 
-// DevDependencies sets up the Python development environment using uv
-func DevDependencies(projectPath string) error {
+// formatCommands returns the uv run arguments that format a project of projectType and
+// check its formatting. Library projects keep the fmt scripts out of their wheel, so they
+// have no fmt entry points and run the script files instead.
+func formatCommands(projectType string) (format, check []string) {
+	if projectType == "library" {
+		return []string{"python", "scripts/fmt.py"}, []string{"python", "scripts/fmt_check.py"}
+	}
+	return []string{"fmt"}, []string{"fmt-check"}
+}
+
+// DevDependencies sets up the Python development environment of a projectType project using uv
+func DevDependencies(projectPath, projectType string) error {
 	fmt.Println("🔧 Setting up development environment...")
 
 	// Check if uv is installed
 	if err := checkUvInstalled(); err != nil {
-		ShowManualInstructions(projectPath)
+		ShowManualInstructions(projectPath, projectType)
 		return err
 	}
+	format, check := formatCommands(projectType)
 
 	// Run uv add --dev ruff pyright
-	cmd := exec.Command("uv", "add", "--dev", "ruff", "pyright")
+	args := append([]string{"add", "--dev"}, config.DevDependencies...)
+	cmd := exec.Command("uv", args...)
 	cmd.Dir = projectPath
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -26,9 +41,9 @@ func DevDependencies(projectPath string) error {
 		return fmt.Errorf("failed to add development dependencies: %w", err)
 	}
 
-	// Run uv run fmt to format the code
+	// Run the formatter
 	fmt.Println("🎨 Formatting code...")
-	fmtCmd := exec.Command("uv", "run", "fmt")
+	fmtCmd := exec.Command("uv", append([]string{"run"}, format...)...)
 	fmtCmd.Dir = projectPath
 	fmtCmd.Stdout = os.Stdout
 	fmtCmd.Stderr = os.Stderr
@@ -38,9 +53,9 @@ func DevDependencies(projectPath string) error {
 		// Don't return error, continue with setup
 	}
 
-	// Run uv run fmt-check to check formatting
+	// Check the formatting
 	fmt.Println("🔍 Checking code formatting...")
-	fmtCheckCmd := exec.Command("uv", "run", "fmt-check")
+	fmtCheckCmd := exec.Command("uv", append([]string{"run"}, check...)...)
 	fmtCheckCmd.Dir = projectPath
 	fmtCheckCmd.Stdout = os.Stdout
 	fmtCheckCmd.Stderr = os.Stderr
@@ -64,21 +79,52 @@ func checkUvInstalled() error {
 }
 
 // ShowManualInstructions displays instructions for manual environment setup
-func ShowManualInstructions(projectPath string) {
+func ShowManualInstructions(projectPath, projectType string) {
+	format, check := formatCommands(projectType)
 	fmt.Println("💡 You can set up the development environment later by running:")
 	fmt.Println("   cd", projectPath)
-	fmt.Println("   uv add --dev ruff pyright")
-	fmt.Println("   uv run fmt")
-	fmt.Println("   uv run fmt-check")
+	fmt.Println("   uv add --dev", strings.Join(config.DevDependencies, " "))
+	fmt.Println("   uv run", strings.Join(format, " "))
+	fmt.Println("   uv run", strings.Join(check, " "))
 }
 
 // FastAPIDependencies installs selected FastAPI dependencies using uv
 func FastAPIDependencies(projectPath string, selectedDeps []string) error {
-	fmt.Println("🚀 Installing FastAPI dependencies...")
+	return installDependencies(projectPath, "FastAPI", selectedDeps)
+}
+
+// ShowManualFastAPIInstructions displays instructions for manual FastAPI dependency setup
+func ShowManualFastAPIInstructions(projectPath string, selectedDeps []string) {
+	showManualDependencyInstructions(projectPath, "FastAPI", selectedDeps)
+}
+
+// FlaskDependencies installs selected Flask dependencies using uv
+func FlaskDependencies(projectPath string, selectedDeps []string) error {
+	return installDependencies(projectPath, "Flask", selectedDeps)
+}
+
+// ShowManualFlaskInstructions displays instructions for manual Flask dependency setup
+func ShowManualFlaskInstructions(projectPath string, selectedDeps []string) {
+	showManualDependencyInstructions(projectPath, "Flask", selectedDeps)
+}
+
+// DataScienceDependencies installs selected data-science dependencies using uv
+func DataScienceDependencies(projectPath string, selectedDeps []string) error {
+	return installDependencies(projectPath, "data-science", selectedDeps)
+}
+
+// ShowManualDataScienceInstructions displays instructions for manual data-science dependency setup
+func ShowManualDataScienceInstructions(projectPath string, selectedDeps []string) {
+	showManualDependencyInstructions(projectPath, "data-science", selectedDeps)
+}
+
+// installDependencies adds the selected dependencies with uv and syncs the environment
+func installDependencies(projectPath, label string, selectedDeps []string) error {
+	fmt.Printf("🚀 Installing %s dependencies...\n", label)
 
 	// Check if uv is installed
 	if err := checkUvInstalled(); err != nil {
-		ShowManualFastAPIInstructions(projectPath, selectedDeps)
+		showManualDependencyInstructions(projectPath, label, selectedDeps)
 		return err
 	}
 
@@ -91,7 +137,7 @@ func FastAPIDependencies(projectPath string, selectedDeps []string) error {
 		cmd.Stderr = os.Stderr
 
 		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to add FastAPI dependencies: %w", err)
+			return fmt.Errorf("failed to add %s dependencies: %w", label, err)
 		}
 	}
 
@@ -105,13 +151,13 @@ func FastAPIDependencies(projectPath string, selectedDeps []string) error {
 		return fmt.Errorf("failed to sync dependencies: %w", err)
 	}
 
-	fmt.Println("✅ FastAPI dependencies installed successfully!")
+	fmt.Printf("✅ %s dependencies installed successfully!\n", label)
 	return nil
 }
 
-// ShowManualFastAPIInstructions displays instructions for manual FastAPI dependency setup
-func ShowManualFastAPIInstructions(projectPath string, selectedDeps []string) {
-	fmt.Println("💡 You can install FastAPI dependencies later by running:")
+// showManualDependencyInstructions displays instructions for installing dependencies by hand
+func showManualDependencyInstructions(projectPath, label string, selectedDeps []string) {
+	fmt.Printf("💡 You can install %s dependencies later by running:\n", label)
 	fmt.Println("   cd", projectPath)
 	if len(selectedDeps) > 0 {
 		fmt.Printf("   uv add")
@@ -122,3 +168,63 @@ func ShowManualFastAPIInstructions(projectPath string, selectedDeps []string) {
 	}
 	fmt.Println("   uv sync --dev")
 }
+
+// RegisterKernel installs ipykernel into the project environment and registers a
+// Jupyter kernel for it under kernelName
+func RegisterKernel(projectPath, kernelName, displayName string) error {
+	fmt.Println("📓 Registering Jupyter kernel...")
+
+	// Check if uv is installed
+	if err := checkUvInstalled(); err != nil {
+		ShowManualKernelInstructions(projectPath, kernelName, displayName)
+		return err
+	}
+
+	// Run uv add --dev ipykernel
+	cmd := exec.Command("uv", "add", "--dev", "ipykernel")
+	cmd.Dir = projectPath
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to add ipykernel: %w", err)
+	}
+
+	// Register the kernel for the current user
+	installCmd := exec.Command("uv", kernelInstallArgs(kernelName, displayName)...)
+	installCmd.Dir = projectPath
+	installCmd.Stdout = os.Stdout
+	installCmd.Stderr = os.Stderr
+
+	if err := installCmd.Run(); err != nil {
+		return fmt.Errorf("failed to register kernel: %w", err)
+	}
+
+	fmt.Printf("✅ Jupyter kernel %q registered!\n", kernelName)
+	return nil
+}
+
+// ShowManualKernelInstructions displays instructions for registering the Jupyter kernel by hand
+func ShowManualKernelInstructions(projectPath, kernelName, displayName string) {
+	fmt.Println("💡 You can register the Jupyter kernel later by running:")
+	fmt.Println("   cd", projectPath)
+	fmt.Println("   uv add --dev ipykernel")
+	fmt.Printf("   uv %s\n", strings.Join(quoteArgs(kernelInstallArgs(kernelName, displayName)), " "))
+}
+
+// kernelInstallArgs returns the uv arguments that register the project's kernel
+func kernelInstallArgs(kernelName, displayName string) []string {
+	return []string{"run", "python", "-m", "ipykernel", "install", "--user", "--name", kernelName, "--display-name", displayName}
+}
+
+// quoteArgs quotes arguments containing spaces so they can be pasted into a shell
+func quoteArgs(args []string) []string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		quoted[i] = arg
+	}
+	return quoted
+}
@@ -0,0 +1,29 @@
+package setup
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatCommands(t *testing.T) {
+	tests := []struct {
+		projectType string
+		format      string
+		check       string
+	}{
+		{"basic", "fmt", "fmt-check"},
+		{"web", "fmt", "fmt-check"},
+		// Library wheels have no fmt entry points, so the scripts are run directly
+		{"library", "python scripts/fmt.py", "python scripts/fmt_check.py"},
+	}
+
+	for _, tt := range tests {
+		format, check := formatCommands(tt.projectType)
+		if got := strings.Join(format, " "); got != tt.format {
+			t.Errorf("formatCommands(%q) format = %q, want %q", tt.projectType, got, tt.format)
+		}
+		if got := strings.Join(check, " "); got != tt.check {
+			t.Errorf("formatCommands(%q) check = %q, want %q", tt.projectType, got, tt.check)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package validation checks the names and versions a project is generated with, and
+// suggests a corrected value when one is invalid
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Pradyothsp/pyinit/internal/config"
+	"github.com/Pradyothsp/pyinit/pkg/template"
+)
+
+// The Python versions generated projects support. Templates use syntax such as
+// "str | None" that needs 3.10.
+const (
+	MinPythonMinor = 10
+	MaxPythonMinor = 14
+)
+
+// Error reports an invalid value and, when there is one, a valid value to use instead
+type Error struct {
+	Message    string
+	Suggestion string
+}
+
+func (e *Error) Error() string {
+	if e.Suggestion == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (try %q)", e.Message, e.Suggestion)
+}
+
+// ProjectName checks that name can be used as a distribution name (PEP 508): ASCII
+// letters, digits, '.', '_' and '-', starting and ending with a letter or digit. Spaces
+// are allowed too, since they become dashes in the project slug.
+func ProjectName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return &Error{Message: "project name is required"}
+	}
+
+	for _, char := range name {
+		if !isAlphanumeric(char) && !strings.ContainsRune(" ._-", char) {
+			return &Error{
+				Message:    fmt.Sprintf("project name cannot contain %q; use ASCII letters, digits, spaces, '.', '_' and '-'", char),
+				Suggestion: suggestProjectName(name),
+			}
+		}
+	}
+
+	if !isAlphanumeric(rune(name[0])) || !isAlphanumeric(rune(name[len(name)-1])) {
+		return &Error{
+			Message:    "project name must start and end with a letter or digit",
+			Suggestion: suggestProjectName(name),
+		}
+	}
+
+	return nil
+}
+
+// suggestProjectName returns the project slug of name when it is a valid project name
+func suggestProjectName(name string) string {
+	suggestion := strings.Trim(config.SanitizeProjectName(name), "-_")
+	if suggestion == "" || ProjectName(suggestion) != nil {
+		return ""
+	}
+	return suggestion
+}
+
+func isAlphanumeric(char rune) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
+
+// identifierPattern matches the ASCII Python identifiers a package can be imported by
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PackageName checks that name can be imported as the project's package: a lowercase
+// Python identifier (PEP 8) that is not a keyword and does not shadow a standard library
+// module
+func PackageName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return &Error{Message: "package name is required"}
+	case !identifierPattern.MatchString(name):
+		return &Error{
+			Message:    fmt.Sprintf("%q is not importable; use ASCII letters, digits and underscores, not starting with a digit", name),
+			Suggestion: suggestPackageName(name),
+		}
+	case name != strings.ToLower(name):
+		return &Error{
+			Message:    fmt.Sprintf("package name %q should be lowercase (PEP 8)", name),
+			Suggestion: suggestPackageName(name),
+		}
+	case template.IsPythonKeyword(name):
+		return &Error{
+			Message:    fmt.Sprintf("%q is a Python keyword", name),
+			Suggestion: suggestPackageName(name),
+		}
+	case stdlibModules[name]:
+		return &Error{
+			Message:    fmt.Sprintf("%q would shadow the standard library module of the same name", name),
+			Suggestion: suggestPackageName(name),
+		}
+	}
+
+	return nil
+}
+
+// suggestPackageName turns name into a valid package name, adding a trailing underscore
+// to names that clash with a keyword or standard library module
+func suggestPackageName(name string) string {
+	suggestion := template.PyIdentifier(config.Transliterate(name))
+	if !identifierPattern.MatchString(suggestion) || strings.Trim(suggestion, "_") == "" {
+		return ""
+	}
+	if template.IsPythonKeyword(suggestion) || stdlibModules[suggestion] {
+		suggestion += "_"
+	}
+	return suggestion
+}
+
+// looseVersionPattern matches the ways a Python version tends to be written:
+// "3.12", "3.12.1", "312", "py312" and "python3.12"
+var looseVersionPattern = regexp.MustCompile(`^(?:py|python)?(\d)\.?(\d+)(?:\.\d+)?$`)
+
+// PythonVersion checks that version is a major.minor version of a supported Python 3
+// release, such as "3.13"
+func PythonVersion(version string) error {
+	match := looseVersionPattern.FindStringSubmatch(strings.ToLower(version))
+	if match == nil {
+		return &Error{
+			Message: fmt.Sprintf("%q is not a Python version such as 3.%d", version, MaxPythonMinor-1),
+		}
+	}
+
+	major, _ := strconv.Atoi(match[1])
+	minor, err := strconv.Atoi(match[2])
+	if major != 3 || err != nil {
+		return &Error{
+			Message:    fmt.Sprintf("Python %s is not supported; use 3.%d to 3.%d", version, MinPythonMinor, MaxPythonMinor),
+			Suggestion: fmt.Sprintf("3.%d", MaxPythonMinor-1),
+		}
+	}
+
+	supported := fmt.Sprintf("3.%d", min(max(minor, MinPythonMinor), MaxPythonMinor))
+	if minor < MinPythonMinor || minor > MaxPythonMinor {
+		return &Error{
+			Message:    fmt.Sprintf("Python 3.%d is not supported; use 3.%d to 3.%d", minor, MinPythonMinor, MaxPythonMinor),
+			Suggestion: supported,
+		}
+	}
+
+	if version != supported {
+		return &Error{
+			Message:    fmt.Sprintf("%q is not a major.minor Python version", version),
+			Suggestion: supported,
+		}
+	}
+
+	return nil
+}
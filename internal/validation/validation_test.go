@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+// checkSuggestion asserts that err is nil for a valid value, or an *Error suggesting suggestion
+func checkSuggestion(t *testing.T, value string, err error, valid bool, suggestion string) {
+	t.Helper()
+
+	if valid {
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", value, err)
+		}
+		return
+	}
+
+	var validationErr *Error
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("%q: error = %v, want a validation error", value, err)
+	}
+	if validationErr.Suggestion != suggestion {
+		t.Errorf("%q: suggestion = %q, want %q (%v)", value, validationErr.Suggestion, suggestion, err)
+	}
+}
+
+func TestProjectName(t *testing.T) {
+	tests := []struct {
+		name       string
+		valid      bool
+		suggestion string
+	}{
+		{name: "my-project", valid: true},
+		{name: "My Project", valid: true},
+		{name: "pyinit.plugins_2", valid: true},
+		{name: "9lives", valid: true},
+		{name: "", valid: false},
+		{name: "Ünïcode App", valid: false, suggestion: "unicode-app"},
+		{name: "my app!", valid: false, suggestion: "my-app"},
+		{name: "-leading", valid: false, suggestion: "leading"},
+		{name: "trailing.", valid: false, suggestion: "trailing"},
+		{name: "日本", valid: false},
+	}
+
+	for _, tt := range tests {
+		checkSuggestion(t, tt.name, ProjectName(tt.name), tt.valid, tt.suggestion)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		name       string
+		valid      bool
+		suggestion string
+	}{
+		{name: "my_pkg", valid: true},
+		{name: "_private", valid: true},
+		{name: "app2", valid: true},
+		{name: "", valid: false},
+		{name: "my-pkg", valid: false, suggestion: "my_pkg"},
+		{name: "2fast", valid: false, suggestion: "_2fast"},
+		{name: "MyPkg", valid: false, suggestion: "my_pkg"},
+		{name: "class", valid: false, suggestion: "class_"},
+		{name: "json", valid: false, suggestion: "json_"},
+		{name: "typing", valid: false, suggestion: "typing_"},
+		{name: "café", valid: false, suggestion: "cafe"},
+		{name: "!!!", valid: false},
+	}
+
+	for _, tt := range tests {
+		checkSuggestion(t, tt.name, PackageName(tt.name), tt.valid, tt.suggestion)
+	}
+}
+
+func TestPythonVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		valid      bool
+		suggestion string
+	}{
+		{version: "3.10", valid: true},
+		{version: "3.13", valid: true},
+		{version: "3.14", valid: true},
+		{version: "3.12.1", valid: false, suggestion: "3.12"},
+		{version: "312", valid: false, suggestion: "3.12"},
+		{version: "py311", valid: false, suggestion: "3.11"},
+		{version: "python3.13", valid: false, suggestion: "3.13"},
+		{version: "3.8", valid: false, suggestion: "3.10"},
+		{version: "3.15", valid: false, suggestion: "3.14"},
+		{version: "2.7", valid: false, suggestion: "3.13"},
+		{version: "3.x", valid: false},
+		{version: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		checkSuggestion(t, tt.version, PythonVersion(tt.version), tt.valid, tt.suggestion)
+	}
+}
@@ -3,10 +3,16 @@ package template
 import (
 	"bytes"
 	"embed"
+	"errors"
 	"fmt"
 	"github.com/Pradyothsp/pyinit"
 	"io"
+	"io/fs"
+	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/flosch/pongo2/v6"
 )
@@ -15,6 +21,7 @@ import (
 type Engine struct {
 	templateDir string
 	loader      pongo2.TemplateLoader
+	set         *pongo2.TemplateSet // Parsed templates, reset whenever the loader changes
 }
 
 // NewEngine creates a new template engine with embedded templates
@@ -33,8 +40,14 @@ type EmbeddedLoader struct {
 	fs embed.FS
 }
 
+// Abs resolves name against the embedded templates directory, see resolveName. Names
+// that are already resolved are returned unchanged, since the template cache resolves
+// names twice.
 func (e *EmbeddedLoader) Abs(base, name string) string {
-	return filepath.Join("templates", name)
+	if strings.HasPrefix(name, "templates/") {
+		return name
+	}
+	return path.Join("templates", resolveName(strings.TrimPrefix(base, "templates/"), name))
 }
 
 func (e *EmbeddedLoader) Get(path string) (io.Reader, error) {
@@ -45,35 +58,302 @@ func (e *EmbeddedLoader) Get(path string) (io.Reader, error) {
 	return bytes.NewReader(content), nil
 }
 
+// Templates lists every embedded template, relative to the template root
+func (e *EmbeddedLoader) Templates() ([]string, error) {
+	return listTemplates(e.fs, "templates")
+}
+
+// Locate describes where an embedded template is read from
+func (e *EmbeddedLoader) Locate(name string) string {
+	return "embedded:" + e.Abs("", name)
+}
+
+// LayeredLoader implements pongo2.TemplateLoader by resolving template names against a
+// directory on disk first and falling back to another loader for anything not found there
+type LayeredLoader struct {
+	dir      string
+	fallback pongo2.TemplateLoader
+}
+
+// NewLayeredLoader creates a loader that overrides fallback with the templates in dir
+func NewLayeredLoader(dir string, fallback pongo2.TemplateLoader) *LayeredLoader {
+	return &LayeredLoader{dir: dir, fallback: fallback}
+}
+
+// Abs keeps template names relative to the template root so each layer can resolve them,
+// see resolveName
+func (l *LayeredLoader) Abs(base, name string) string {
+	return resolveName(base, filepath.ToSlash(name))
+}
+
+func (l *LayeredLoader) Get(path string) (io.Reader, error) {
+	if filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, "../") {
+		return nil, fmt.Errorf("template %s is outside the template root", path)
+	}
+
+	content, err := os.ReadFile(filepath.Join(l.dir, filepath.FromSlash(path)))
+	if err == nil {
+		return bytes.NewReader(content), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return l.fallback.Get(l.fallback.Abs("", path))
+}
+
+// Locate returns the path of the file a template is read from when it is in dir, and
+// asks the fallback loader otherwise
+func (l *LayeredLoader) Locate(name string) string {
+	if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return name
+	}
+
+	file := filepath.Join(l.dir, filepath.FromSlash(name))
+	if _, err := os.Stat(file); err == nil {
+		return file
+	}
+	return locateTemplate(l.fallback, l.fallback.Abs("", name))
+}
+
+// Templates lists the templates in dir together with those of the fallback loader
+func (l *LayeredLoader) Templates() ([]string, error) {
+	var names []string
+	if lister, ok := l.fallback.(templateLister); ok {
+		fallbackNames, err := lister.Templates()
+		if err != nil {
+			return nil, err
+		}
+		names = fallbackNames
+	}
+
+	dirNames, err := listTemplates(os.DirFS(l.dir), ".")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+	for _, name := range dirNames {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// resolveName resolves a template name to a path relative to the template root. Names
+// such as "core/_partials/README.md.j2" already are; names starting with "./" or "../"
+// are relative to the directory of base, the root-relative name of the template that
+// extends or includes them.
+func resolveName(base, name string) string {
+	if base != "" && (strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../")) {
+		return path.Join(path.Dir(base), name)
+	}
+	return path.Clean(name)
+}
+
+// templateLister is implemented by loaders that can enumerate their templates for Precompile
+type templateLister interface {
+	Templates() ([]string, error)
+}
+
+// templateLocator is implemented by loaders that can say where a template is read from,
+// so errors can point at the file to fix
+type templateLocator interface {
+	Locate(name string) string
+}
+
+// locateTemplate describes where loader reads the resolved template name from
+func locateTemplate(loader pongo2.TemplateLoader, name string) string {
+	if locator, ok := loader.(templateLocator); ok {
+		return locator.Locate(name)
+	}
+	return name
+}
+
+// listTemplates returns the .j2 files under root in fsys, relative to root
+func listTemplates(fsys fs.FS, root string) ([]string, error) {
+	var names []string
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".j2") {
+			return nil
+		}
+		names = append(names, strings.TrimPrefix(p, root+"/"))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return names, nil
+}
+
+// templateSet returns the engine's template set, creating it on first use. The set
+// caches every template it parses, so each file is only read and parsed once.
+func (e *Engine) templateSet() *pongo2.TemplateSet {
+	if e.set == nil {
+		e.set = pongo2.NewSet("pyinit", e.loader)
+		e.set.Globals.Update(templateGlobals())
+	}
+	return e.set
+}
+
+// Precompile parses every template the engine can load and reports all syntax
+// errors at once. Parsed templates stay cached for later renders.
+func (e *Engine) Precompile() error {
+	lister, ok := e.loader.(templateLister)
+	if !ok {
+		return fmt.Errorf("template loader %T cannot list its templates", e.loader)
+	}
+
+	names, err := lister.Templates()
+	if err != nil {
+		return err
+	}
+
+	set := e.templateSet()
+	var errs []error
+	for _, name := range names {
+		if _, err := set.FromCache(name); err != nil {
+			errs = append(errs, e.templateError("parse", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // RenderTemplate renders a template file with the given context
 func (e *Engine) RenderTemplate(templateFile string, context map[string]interface{}) (string, error) {
-	// Create a template set with our loader
-	set := pongo2.NewSet("pyinit", e.loader)
-
-	// Get the template
-	template, err := set.FromFile(templateFile)
+	// Get the template, parsing it only the first time it is used
+	template, err := e.templateSet().FromCache(templateFile)
 	if err != nil {
-		return "", fmt.Errorf("failed to load template %s: %w", templateFile, err)
+		return "", e.templateError("load", templateFile, err)
 	}
 
 	// Render the template
 	output, err := template.Execute(pongo2.Context(context))
 	if err != nil {
-		return "", fmt.Errorf("failed to render template %s: %w", templateFile, err)
+		return "", e.templateError("render", templateFile, err)
+	}
+
+	return output, nil
+}
+
+// TemplateError reports a template that failed to load, parse or render, with the file
+// the failure is in and the file the output was meant for
+type TemplateError struct {
+	Op       string // "load", "parse" or "render"
+	Template string // Name of the template being rendered, relative to the template root
+	Source   string // File the failure is in: a path on disk or "embedded:templates/..."
+	Line     int    // Position of the failure in Source, zero when unknown
+	Column   int
+	Output   string // Path the rendered template was meant for, set by callers that know it
+	Err      error
+}
+
+func (e *TemplateError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to %s template %s", e.Op, e.Template)
+	if e.Output != "" {
+		fmt.Fprintf(&b, " for %s", e.Output)
+	}
+	if e.Source != "" {
+		fmt.Fprintf(&b, ": %s", e.Source)
+		if e.Line > 0 {
+			fmt.Fprintf(&b, ":%d:%d", e.Line, e.Column)
+		}
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	return b.String()
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// templateError wraps an error from loading or rendering templateFile in a TemplateError.
+// Pongo2 errors carry the template they happened in, which is an included or extended
+// template when the failure is there, and the line and column.
+func (e *Engine) templateError(op, templateFile string, err error) *TemplateError {
+	templateErr := &TemplateError{
+		Op:       op,
+		Template: templateFile,
+		Source:   locateTemplate(e.loader, e.loader.Abs("", templateFile)),
+		Err:      err,
+	}
+
+	var pongoErr *pongo2.Error
+	if errors.As(err, &pongoErr) {
+		if pongoErr.Filename != "" && pongoErr.Filename != "<string>" {
+			templateErr.Source = locateTemplate(e.loader, pongoErr.Filename)
+		}
+		templateErr.Line = pongoErr.Line
+		templateErr.Column = pongoErr.Column
+		if pongoErr.OrigError != nil {
+			templateErr.Err = pongoErr.OrigError
+		}
+	}
+
+	return templateErr
+}
+
+// RenderString renders a template given as a string, such as a templated output path
+func (e *Engine) RenderString(source string, context map[string]interface{}) (string, error) {
+	template, err := e.templateSet().FromString(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %q: %w", source, err)
+	}
+
+	output, err := template.Execute(pongo2.Context(context))
+	if err != nil {
+		return "", fmt.Errorf("failed to render %q: %w", source, err)
 	}
 
 	return output, nil
 }
 
-// SetTemplateDir sets a custom template directory
+// ReadTemplate returns the unrendered source of a file in the template tree, such as a manifest
+func (e *Engine) ReadTemplate(name string) ([]byte, error) {
+	reader, err := e.loader.Get(e.loader.Abs("", name))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+// HasTemplate reports whether templateFile can be loaded
+func (e *Engine) HasTemplate(templateFile string) bool {
+	_, err := e.loader.Get(e.loader.Abs("", templateFile))
+	return err == nil
+}
+
+// SetTemplateDir layers a custom template directory over the engine's current
+// templates, so it only needs to contain the templates it overrides. Later
+// directories take precedence over earlier ones.
 func (e *Engine) SetTemplateDir(dir string) error {
 	absDir, err := filepath.Abs(dir)
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path for template directory: %w", err)
 	}
 
+	info, err := os.Stat(absDir)
+	if err != nil {
+		return fmt.Errorf("failed to open template directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("template directory %s is not a directory", absDir)
+	}
+
 	e.templateDir = absDir
-	e.loader = pongo2.MustNewLocalFileSystemLoader(absDir)
+	e.loader = NewLayeredLoader(absDir, e.loader)
+	e.set = nil
 
 	return nil
 }
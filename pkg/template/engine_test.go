@@ -3,10 +3,14 @@ package template
 import (
 	"bytes"
 	"embed"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 //go:embed  test_templates/*
@@ -76,6 +80,10 @@ func TestEmbeddedLoader_Abs(t *testing.T) {
 		{"", "basic/README.md.j2", "templates/basic/README.md.j2"},
 		{"templates", "core/python-version.j2", "templates/core/python-version.j2"},
 		{"/some/path", "web/fastapi/main.py.j2", "templates/web/fastapi/main.py.j2"},
+		{"", "templates/basic/README.md.j2", "templates/basic/README.md.j2"},
+		{"templates/core/_partials/README.md.j2", "./readme/commands.j2", "templates/core/_partials/readme/commands.j2"},
+		{"templates/web/fastapi/pyproject.toml.j2", "../../core/_partials/pyproject.toml.j2", "templates/core/_partials/pyproject.toml.j2"},
+		{"templates/web/fastapi/pyproject.toml.j2", "core/_partials/pyproject.toml.j2", "templates/core/_partials/pyproject.toml.j2"},
 	}
 
 	for _, tt := range tests {
@@ -394,3 +402,558 @@ func BenchmarkEngine_RenderTemplate_RealTemplate(b *testing.B) {
 	}
 }
 
+
+func TestEngine_HasTemplate(t *testing.T) {
+	engine := NewEngine()
+
+	if !engine.HasTemplate("basic/README.md.j2") {
+		t.Error("Expected embedded basic/README.md.j2 to exist")
+	}
+	if engine.HasTemplate("basic/missing.j2") {
+		t.Error("Expected basic/missing.j2 to be reported missing")
+	}
+}
+
+func TestEngine_SetTemplateDir_LayersOverEmbedded(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "basic"), 0755); err != nil {
+		t.Fatalf("Failed to create override dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "basic", "README.md.j2"), []byte("Custom {{ project_name }}"), 0644); err != nil {
+		t.Fatalf("Failed to write override template: %v", err)
+	}
+
+	engine := NewEngine()
+	if err := engine.SetTemplateDir(dir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	context := map[string]interface{}{"project_name": "demo", "python_version": "3.12"}
+
+	// The override wins
+	output, err := engine.RenderTemplate("basic/README.md.j2", context)
+	if err != nil {
+		t.Fatalf("RenderTemplate failed: %v", err)
+	}
+	if output != "Custom demo" {
+		t.Errorf("Expected override output, got %q", output)
+	}
+
+	// Anything not overridden falls back to the embedded templates
+	output, err = engine.RenderTemplate("core/python-version.j2", context)
+	if err != nil {
+		t.Fatalf("RenderTemplate failed for embedded fallback: %v", err)
+	}
+	if strings.TrimSpace(output) != "3.12" {
+		t.Errorf("Expected embedded python-version output, got %q", output)
+	}
+}
+
+func TestEngine_SetTemplateDir_LaterDirectoriesWin(t *testing.T) {
+	writeTemplate := func(dir, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "greeting.j2"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	pack, user := t.TempDir(), t.TempDir()
+	writeTemplate(pack, "pack")
+	if err := os.WriteFile(filepath.Join(pack, "farewell.j2"), []byte("pack farewell"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	writeTemplate(user, "user")
+
+	engine := NewEngine()
+	for _, dir := range []string{pack, user} {
+		if err := engine.SetTemplateDir(dir); err != nil {
+			t.Fatalf("SetTemplateDir(%s) failed: %v", dir, err)
+		}
+	}
+
+	for name, want := range map[string]string{"greeting.j2": "user", "farewell.j2": "pack farewell"} {
+		output, err := engine.RenderTemplate(name, nil)
+		if err != nil {
+			t.Fatalf("RenderTemplate(%s) failed: %v", name, err)
+		}
+		if output != want {
+			t.Errorf("RenderTemplate(%s) = %q, want %q", name, output, want)
+		}
+	}
+}
+
+func TestEngine_RenderString(t *testing.T) {
+	engine := NewEngine()
+
+	output, err := engine.RenderString("{{ main_dir_name }}/__init__.py", map[string]interface{}{"main_dir_name": "demo"})
+	if err != nil {
+		t.Fatalf("RenderString failed: %v", err)
+	}
+	if output != "demo/__init__.py" {
+		t.Errorf("RenderString() = %q, want %q", output, "demo/__init__.py")
+	}
+
+	if _, err := engine.RenderString("{% if %}", nil); err == nil {
+		t.Error("Expected error for invalid template syntax")
+	}
+}
+
+func TestEngine_SetTemplateDir_Missing(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetTemplateDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for a template directory that does not exist")
+	}
+}
+
+func TestLayeredLoader_RejectsPathsOutsideRoot(t *testing.T) {
+	loader := NewLayeredLoader(t.TempDir(), NewMockLoader(map[string]string{}))
+
+	for _, name := range []string{"../secret.j2", "basic/../../secret.j2"} {
+		if _, err := loader.Get(loader.Abs("", name)); err == nil {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestNameFilters(t *testing.T) {
+	tests := []struct {
+		in                                   string
+		snake, kebab, pascal, pep503, pyname string
+	}{
+		{in: "My Project", snake: "my_project", kebab: "my-project", pascal: "MyProject", pep503: "my project", pyname: "my_project"},
+		{in: "my-awesome_app", snake: "my_awesome_app", kebab: "my-awesome-app", pascal: "MyAwesomeApp", pep503: "my-awesome-app", pyname: "my_awesome_app"},
+		{in: "HTTPServer", snake: "http_server", kebab: "http-server", pascal: "HttpServer", pep503: "httpserver", pyname: "http_server"},
+		{in: "fastAPI v2", snake: "fast_api_v2", kebab: "fast-api-v2", pascal: "FastApiV2", pep503: "fastapi v2", pyname: "fast_api_v2"},
+		{in: "My_Project.Core", snake: "my_project_core", kebab: "my-project-core", pascal: "MyProjectCore", pep503: "my-project-core", pyname: "my_project_core"},
+		{in: "2fast", snake: "2fast", kebab: "2fast", pascal: "2fast", pep503: "2fast", pyname: "_2fast"},
+		{in: "class", snake: "class", kebab: "class", pascal: "Class", pep503: "class", pyname: "class_"},
+		{in: "---", snake: "", kebab: "", pascal: "", pep503: "-", pyname: "_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			checks := map[string][2]string{
+				"snake":            {Snake(tt.in), tt.snake},
+				"kebab":            {Kebab(tt.in), tt.kebab},
+				"pascal":           {Pascal(tt.in), tt.pascal},
+				"pep503_normalize": {PEP503Normalize(tt.in), tt.pep503},
+				"py_identifier":    {PyIdentifier(tt.in), tt.pyname},
+			}
+			for name, check := range checks {
+				if check[0] != check[1] {
+					t.Errorf("%s(%q) = %q, want %q", name, tt.in, check[0], check[1])
+				}
+			}
+		})
+	}
+}
+
+func TestPythonTag(t *testing.T) {
+	tests := map[string]string{
+		"3.12":   "py312",
+		"3.9":    "py39",
+		"3.13.1": "py313",
+		" 3.11 ": "py311",
+		"3":      "3",
+		"latest": "latest",
+		"3.x":    "3.x",
+	}
+
+	for in, want := range tests {
+		if got := PythonTag(in); got != want {
+			t.Errorf("PythonTag(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPEP440(t *testing.T) {
+	tests := map[string]string{
+		"1.0":                 "1.0",
+		"v1.0.0":              "1.0.0",
+		"01.002.0":            "1.2.0",
+		"1.0-ALPHA.1":         "1.0a1",
+		"1.0beta":             "1.0b0",
+		"1.0c2":               "1.0rc2",
+		"2.0-preview_3":       "2.0rc3",
+		"1.0-1":               "1.0.post1",
+		"1.0.rev":             "1.0.post0",
+		"1.0-post.2.dev-03":   "1.0.post2.dev3",
+		"0!1.0":               "1.0",
+		"2!1.0rc1+Ubuntu-1_2": "2!1.0rc1+ubuntu.1.2",
+	}
+	for in, want := range tests {
+		got, err := PEP440(in)
+		if err != nil || got != want {
+			t.Errorf("PEP440(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "latest", "1.0.x", "1.0-gamma1", "1.0+", "1..0"} {
+		if got, err := PEP440(in); err == nil {
+			t.Errorf("PEP440(%q) = %q, want an error", in, got)
+		}
+	}
+}
+
+// useFixedClock makes the time-based globals deterministic for the test
+func useFixedClock(t *testing.T) {
+	t.Helper()
+
+	original := now
+	now = func() time.Time { return time.Date(2031, time.March, 4, 5, 6, 7, 0, time.UTC) }
+	t.Cleanup(func() { now = original })
+}
+
+func TestLicenseHeader(t *testing.T) {
+	useFixedClock(t)
+
+	want := "# Copyright (c) 2031 Jane Doe\n# SPDX-License-Identifier: MIT"
+	if got := LicenseHeader("MIT", "Jane Doe"); got != want {
+		t.Errorf("LicenseHeader() = %q, want %q", got, want)
+	}
+}
+
+func TestEngine_FiltersAndGlobals(t *testing.T) {
+	useFixedClock(t)
+
+	engine := &Engine{loader: NewMockLoader(map[string]string{
+		"names.j2":   `{{ project_name|snake }} {{ project_name|kebab }} {{ project_name|pascal }} {{ project_name|pep503_normalize }} {{ project_name|py_identifier }} {{ python_version|python_tag }} {{ "v1.0-RC.1"|pep440 }}`,
+		"globals.j2": `{{ current_year }} {{ now()|date:"2006-01-02" }}` + "\n" + `{{ license_header("Apache-2.0", user_name) }}`,
+	})}
+
+	context := map[string]interface{}{
+		"project_name":   "My Cool_App",
+		"python_version": "3.12",
+		"user_name":      "Jane Doe",
+	}
+
+	tests := map[string]string{
+		"names.j2":   "my_cool_app my-cool-app MyCoolApp my cool-app my_cool_app py312 1.0rc1",
+		"globals.j2": "2031 2031-03-04\n# Copyright (c) 2031 Jane Doe\n# SPDX-License-Identifier: Apache-2.0",
+	}
+
+	for name, want := range tests {
+		output, err := engine.RenderTemplate(name, context)
+		if err != nil {
+			t.Fatalf("RenderTemplate(%s) failed: %v", name, err)
+		}
+		if output != want {
+			t.Errorf("RenderTemplate(%s) = %q, want %q", name, output, want)
+		}
+	}
+
+	// Invalid versions fail the render instead of reaching the output
+	if _, err := engine.RenderString(`{{ "latest"|pep440 }}`, context); err == nil || !strings.Contains(err.Error(), "PEP 440") {
+		t.Errorf("RenderString() error = %v, want an invalid version error", err)
+	}
+
+	// Filters and globals work in template strings such as manifest output paths too
+	output, err := engine.RenderString("{{ project_name|snake }}/{{ current_year }}.py", context)
+	if err != nil {
+		t.Fatalf("RenderString failed: %v", err)
+	}
+	if output != "my_cool_app/2031.py" {
+		t.Errorf("RenderString() = %q, want %q", output, "my_cool_app/2031.py")
+	}
+}
+
+func TestEngine_Precompile_EmbeddedTemplates(t *testing.T) {
+	// Every template shipped in templates/ must parse, whichever project kind uses it
+	if err := NewEngine().Precompile(); err != nil {
+		t.Fatalf("Precompile() failed for the embedded templates:\n%v", err)
+	}
+}
+
+func TestEngine_Precompile_ReportsEverySyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	templates := map[string]string{
+		"broken-if.j2":          "{% if %}\n",
+		"nested/broken-for.j2":  "{% for item in %}{% endfor %}\n",
+		"nested/fine.j2":        "{{ project_name }}\n",
+		"nested/not-a-template": "{% if %}",
+	}
+	for name, content := range templates {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	engine := NewEngine()
+	if err := engine.SetTemplateDir(dir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	err := engine.Precompile()
+	if err == nil {
+		t.Fatal("Expected Precompile() to report the broken templates")
+	}
+	for _, name := range []string{"broken-if.j2", "nested/broken-for.j2"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Precompile() error does not mention %s:\n%v", name, err)
+		}
+	}
+	for _, name := range []string{"nested/fine.j2", "not-a-template"} {
+		if strings.Contains(err.Error(), name) {
+			t.Errorf("Precompile() error mentions %s, which parses or is not a template:\n%v", name, err)
+		}
+	}
+}
+
+func TestEngine_Precompile_RequiresListableLoader(t *testing.T) {
+	engine := &Engine{loader: NewMockLoader(map[string]string{})}
+	if err := engine.Precompile(); err == nil {
+		t.Error("Expected Precompile() to fail for a loader that cannot list its templates")
+	}
+}
+
+func TestEngine_RenderTemplate_CachesParsedTemplates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "greeting.j2")
+	if err := os.WriteFile(path, []byte("hello {{ name }}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	engine := NewEngine()
+	if err := engine.SetTemplateDir(dir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	render := func() string {
+		t.Helper()
+		output, err := engine.RenderTemplate("greeting.j2", map[string]interface{}{"name": "pyinit"})
+		if err != nil {
+			t.Fatalf("RenderTemplate failed: %v", err)
+		}
+		return output
+	}
+
+	if got := render(); got != "hello pyinit" {
+		t.Fatalf("RenderTemplate() = %q, want %q", got, "hello pyinit")
+	}
+
+	// The parsed template is reused, so later edits on disk are not picked up...
+	if err := os.WriteFile(path, []byte("goodbye {{ name }}"), 0644); err != nil {
+		t.Fatalf("Failed to rewrite template: %v", err)
+	}
+	if got := render(); got != "hello pyinit" {
+		t.Errorf("RenderTemplate() = %q, want the cached %q", got, "hello pyinit")
+	}
+
+	// ...until another template directory changes what the engine loads
+	if err := engine.SetTemplateDir(t.TempDir()); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+	if got := render(); got != "goodbye pyinit" {
+		t.Errorf("RenderTemplate() = %q, want %q after SetTemplateDir", got, "goodbye pyinit")
+	}
+}
+
+func TestEngine_UndefinedVariables(t *testing.T) {
+	engine := &Engine{loader: NewMockLoader(map[string]string{
+		"template.j2": `{# {{ commented_out }} #}
+{{ project_name|default:fallback_name|upper }} {{ config.debug }} {{ "literal" }} {{ 1e3 }}
+{% if use_docker and not skip_docker or project_type == "web" %}docker{% endif %}
+{% for key, value in settings.items reversed %}{{ key }}={{ value }} {{ forloop.Counter }}{% endfor %}
+{% set greeting = "Hello " + user_name %}{{ greeting }}
+{% with author=user_name year=current_year %}{{ author }} {{ year }}{% endwith %}
+{{ license_header("MIT", holder) }}
+{% verbatim %}{{ not_a_variable }}{% endverbatim %}`,
+	})}
+
+	context := map[string]interface{}{"project_name": "demo", "user_name": "Jane", "project_type": "web"}
+
+	undefined, err := engine.UndefinedVariables("template.j2", context)
+	if err != nil {
+		t.Fatalf("UndefinedVariables failed: %v", err)
+	}
+
+	want := []string{"config", "fallback_name", "holder", "settings", "skip_docker", "use_docker"}
+	if strings.Join(undefined, ",") != strings.Join(want, ",") {
+		t.Errorf("UndefinedVariables() = %v, want %v", undefined, want)
+	}
+
+	if _, err := engine.UndefinedVariables("missing.j2", context); err == nil {
+		t.Error("Expected error for a missing template")
+	}
+}
+
+func TestEngine_UndefinedVariables_NestedContext(t *testing.T) {
+	engine := &Engine{loader: NewMockLoader(map[string]string{
+		"template.j2": `{{ project.name }} {{ project.nmae }} {{ author.name|upper }}
+{% if features.venv and not features.docker %}venv{% endif %}
+{% for dep in deps.runtmie %}{{ dep.name }}{% endfor %}{% for dep in deps.runtime %}{{ dep }}{% endfor %}
+{{ project.name.first }} {{ python.Version }} {{ python.Minor }} {{ answers.team_name }} {{ missing.key }}`,
+	})}
+
+	context := map[string]interface{}{
+		"project":  map[string]interface{}{"name": "demo"},
+		"author":   map[string]interface{}{"name": "Jane"},
+		"features": map[string]interface{}{"venv": true},
+		"deps":     map[string]interface{}{"runtime": []string{"requests"}},
+		"python":   struct{ Version string }{"3.13"},
+		"answers":  map[string]string{"team_name": "payments"},
+	}
+
+	undefined, err := engine.UndefinedVariables("template.j2", context)
+	if err != nil {
+		t.Fatalf("UndefinedVariables failed: %v", err)
+	}
+
+	want := []string{"deps.runtmie", "features.docker", "missing", "project.name.first", "project.nmae", "python.Minor"}
+	if strings.Join(undefined, ",") != strings.Join(want, ",") {
+		t.Errorf("UndefinedVariables() = %v, want %v", undefined, want)
+	}
+}
+
+func TestEngine_UndefinedVariablesString(t *testing.T) {
+	engine := &Engine{loader: NewMockLoader(map[string]string{"partial.j2": `{{ partial_only }}`})}
+
+	context := map[string]interface{}{"project": map[string]interface{}{"framework": "click"}}
+	undefined, err := engine.UndefinedVariablesString(`{% if project.framework == "click" or cli_framework %}{% include "partial.j2" %}{% endif %}`, context)
+	if err != nil {
+		t.Fatalf("UndefinedVariablesString failed: %v", err)
+	}
+	want := []string{"cli_framework", "partial_only"}
+	if strings.Join(undefined, ",") != strings.Join(want, ",") {
+		t.Errorf("UndefinedVariablesString() = %v, want %v", undefined, want)
+	}
+}
+
+func TestEngine_UndefinedVariables_FollowsExtendsAndIncludes(t *testing.T) {
+	engine := &Engine{loader: NewMockLoader(map[string]string{
+		"base.j2":    `{{ project_name }} {% block body %}{{ base_only }}{% endblock %} {% include "partial.j2" with cli="app" %}`,
+		"partial.j2": `{{ cli }} {{ partial_only }}`,
+		"child.j2":   `{% extends "base.j2" %}{% block body %}{{ block.Super }} {{ child_only }}{% endblock %}`,
+		"broken.j2":  `{% include "missing.j2" %}`,
+	})}
+
+	undefined, err := engine.UndefinedVariables("child.j2", map[string]interface{}{"project_name": "demo"})
+	if err != nil {
+		t.Fatalf("UndefinedVariables failed: %v", err)
+	}
+	want := []string{"base_only", "child_only", "partial_only"}
+	if strings.Join(undefined, ",") != strings.Join(want, ",") {
+		t.Errorf("UndefinedVariables() = %v, want %v", undefined, want)
+	}
+
+	if _, err := engine.UndefinedVariables("broken.j2", nil); err == nil || !strings.Contains(err.Error(), "missing.j2") {
+		t.Errorf("UndefinedVariables() error = %v, want the missing include reported", err)
+	}
+}
+
+func TestLayeredLoader_Abs(t *testing.T) {
+	loader := NewLayeredLoader(t.TempDir(), NewMockLoader(map[string]string{}))
+
+	tests := []struct {
+		base     string
+		name     string
+		expected string
+	}{
+		{"", "basic/README.md.j2", "basic/README.md.j2"},
+		{"web/fastapi/README.md.j2", "core/_partials/README.md.j2", "core/_partials/README.md.j2"},
+		{"core/_partials/README.md.j2", "./readme/commands.j2", "core/_partials/readme/commands.j2"},
+		{"web/fastapi/README.md.j2", "../../core/_partials/README.md.j2", "core/_partials/README.md.j2"},
+		{"web/fastapi/README.md.j2", "../../../secret.j2", "../secret.j2"},
+	}
+
+	for _, tt := range tests {
+		if result := loader.Abs(tt.base, tt.name); result != tt.expected {
+			t.Errorf("Abs(%q, %q) = %q, want %q", tt.base, tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestEngine_ExtendsAndRelativeIncludes(t *testing.T) {
+	dir := t.TempDir()
+	templates := map[string]string{
+		// A base template on disk that includes a sibling partial and one from the embedded templates
+		"core/_partials/base.txt.j2": `{% block title %}untitled{% endblock %}
+{% include "./footer.j2" %}{% include "./readme/development-commands.sh.j2" %}`,
+		"core/_partials/footer.j2": "footer for {{ project_name }}\n",
+		"web/app/page.txt.j2":      `{% extends "../../core/_partials/base.txt.j2" %}{% block title %}{{ project_name }} ({{ block.Super }}){% endblock %}`,
+	}
+	for name, content := range templates {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	engine := NewEngine()
+	if err := engine.SetTemplateDir(dir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	output, err := engine.RenderTemplate("web/app/page.txt.j2", map[string]interface{}{"project_name": "demo"})
+	if err != nil {
+		t.Fatalf("RenderTemplate failed: %v", err)
+	}
+	if !strings.HasPrefix(output, "demo (untitled)\nfooter for demo\n# Format code\n") {
+		t.Errorf("RenderTemplate() = %q, want the child title, the sibling partial and the embedded partial", output)
+	}
+}
+
+func TestEngine_RenderTemplate_ErrorLocation(t *testing.T) {
+	dir := t.TempDir()
+	templates := map[string]string{
+		"app/page.j2":    "title\n{% include \"./_body.j2\" %}",
+		"app/_body.j2":   "body\n  {{ project_name.missing() }}",
+		"app/broken.j2":  "{% if %}",
+		"app/extends.j2": `{% extends "core/_partials/README.md.j2" %}{% block summary %}{{ project_name|no_such_filter }}{% endblock %}`,
+	}
+	for name, content := range templates {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	engine := NewEngine()
+	if err := engine.SetTemplateDir(dir); err != nil {
+		t.Fatalf("SetTemplateDir failed: %v", err)
+	}
+
+	tests := []struct {
+		template string
+		op       string
+		source   string
+		line     int
+	}{
+		// Failures in included templates point at the included file
+		{"app/page.j2", "render", filepath.Join(dir, "app", "_body.j2"), 2},
+		{"app/broken.j2", "load", filepath.Join(dir, "app", "broken.j2"), 1},
+		{"app/extends.j2", "load", filepath.Join(dir, "app", "extends.j2"), 1},
+		{"missing/page.j2", "load", "embedded:templates/missing/page.j2", 0},
+	}
+
+	for _, tt := range tests {
+		_, err := engine.RenderTemplate(tt.template, map[string]interface{}{"project_name": "demo"})
+
+		var templateErr *TemplateError
+		if !errors.As(err, &templateErr) {
+			t.Errorf("RenderTemplate(%s) error = %v, want a TemplateError", tt.template, err)
+			continue
+		}
+		if templateErr.Op != tt.op || templateErr.Template != tt.template || templateErr.Source != tt.source || templateErr.Line != tt.line {
+			t.Errorf("RenderTemplate(%s) error = %+v, want %s of %s at line %d", tt.template, templateErr, tt.op, tt.source, tt.line)
+		}
+		if tt.line > 0 && !strings.Contains(err.Error(), fmt.Sprintf("%s:%d:", tt.source, tt.line)) {
+			t.Errorf("RenderTemplate(%s) error = %q, want it to contain the source path and line", tt.template, err)
+		}
+	}
+
+	err := &TemplateError{Op: "render", Template: "a.j2", Source: "embedded:templates/a.j2", Line: 3, Column: 7, Output: "/tmp/p/a.py", Err: errors.New("boom")}
+	if want := "failed to render template a.j2 for /tmp/p/a.py: embedded:templates/a.j2:3:7: boom"; err.Error() != want {
+		t.Errorf("TemplateError.Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,241 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// stringFilters are the pyinit filters that map one string to another, such as
+// {{ project_name|snake }}
+var stringFilters = map[string]func(string) string{
+	"snake":            Snake,
+	"kebab":            Kebab,
+	"pascal":           Pascal,
+	"pep503_normalize": PEP503Normalize,
+	"py_identifier":    PyIdentifier,
+	"python_tag":       PythonTag,
+}
+
+// Pongo2 filters are global, so they are registered once for every template set
+func init() {
+	for name, fn := range stringFilters {
+		if err := pongo2.RegisterFilter(name, stringFilter(fn)); err != nil {
+			panic(fmt.Sprintf("failed to register filter %s: %v", name, err))
+		}
+	}
+	if err := pongo2.RegisterFilter("pep440", pep440Filter); err != nil {
+		panic(fmt.Sprintf("failed to register filter pep440: %v", err))
+	}
+}
+
+// pep440Filter normalizes a version with PEP440, failing the render for invalid versions
+func pep440Filter(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	version, err := PEP440(in.String())
+	if err != nil {
+		return nil, &pongo2.Error{Sender: "filter:pep440", OrigError: err}
+	}
+	return pongo2.AsValue(version), nil
+}
+
+// stringFilter adapts a string function to a pongo2 filter
+func stringFilter(fn func(string) string) pongo2.FilterFunction {
+	return func(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+		return pongo2.AsValue(fn(in.String())), nil
+	}
+}
+
+// now is the clock behind the current_year and now globals
+var now = time.Now
+
+// templateGlobals are the pyinit globals available to every template
+func templateGlobals() pongo2.Context {
+	return pongo2.Context{
+		"current_year":   now().Year(),
+		"now":            now,
+		"license_header": LicenseHeader,
+	}
+}
+
+// splitWords breaks a name into lowercase words at separators, case changes and
+// acronym boundaries: "HTTPServer v2-beta" becomes [http server v2 beta]
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := current[len(current)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
+
+// Snake converts a name to snake_case: "My Project" becomes "my_project"
+func Snake(s string) string {
+	return strings.Join(splitWords(s), "_")
+}
+
+// Kebab converts a name to kebab-case: "My Project" becomes "my-project"
+func Kebab(s string) string {
+	return strings.Join(splitWords(s), "-")
+}
+
+// Pascal converts a name to PascalCase: "my_project" becomes "MyProject"
+func Pascal(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// pep503Separators are the runs PEP 503 collapses into a single dash
+var pep503Separators = regexp.MustCompile(`[-_.]+`)
+
+// PEP503Normalize normalizes a distribution name the way package indexes compare
+// them: "My_Project.Core" becomes "my-project-core"
+func PEP503Normalize(s string) string {
+	return strings.ToLower(pep503Separators.ReplaceAllString(s, "-"))
+}
+
+// pep440Pattern matches the versions PEP 440 accepts, in any of their permitted spellings
+var pep440Pattern = regexp.MustCompile(`(?i)^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440PreReleases maps the spellings of pre-release labels to their normal form
+var pep440PreReleases = map[string]string{
+	"a": "a", "alpha": "a", "b": "b", "beta": "b", "c": "rc", "rc": "rc", "pre": "rc", "preview": "rc",
+}
+
+// PEP440 returns the normal form of a version: "v1.0-ALPHA.1" becomes "1.0a1" and
+// "2.01-3" becomes "2.1.post3". Versions PEP 440 does not accept are an error.
+func PEP440(version string) (string, error) {
+	match := pep440Pattern.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return "", fmt.Errorf("%q is not a valid PEP 440 version", version)
+	}
+	epoch, release, preLabel, preNumber := match[1], match[2], match[3], match[4]
+	postImplicit, postLabel, postNumber := match[5], match[6], match[7]
+	devLabel, devNumber, local := match[8], match[9], match[10]
+
+	var b strings.Builder
+	if epoch != "" && trimNumber(epoch) != "0" {
+		b.WriteString(trimNumber(epoch) + "!")
+	}
+
+	parts := strings.Split(release, ".")
+	for i, part := range parts {
+		parts[i] = trimNumber(part)
+	}
+	b.WriteString(strings.Join(parts, "."))
+
+	if preLabel != "" {
+		b.WriteString(pep440PreReleases[strings.ToLower(preLabel)] + trimNumber(preNumber))
+	}
+	switch {
+	case postImplicit != "":
+		b.WriteString(".post" + trimNumber(postImplicit))
+	case postLabel != "":
+		b.WriteString(".post" + trimNumber(postNumber))
+	}
+	if devLabel != "" {
+		b.WriteString(".dev" + trimNumber(devNumber))
+	}
+	if local != "" {
+		b.WriteString("+" + strings.ToLower(pep503Separators.ReplaceAllString(local, ".")))
+	}
+
+	return b.String(), nil
+}
+
+// trimNumber drops the leading zeros of a number, treating an omitted number as 0
+func trimNumber(digits string) string {
+	if trimmed := strings.TrimLeft(digits, "0"); trimmed != "" {
+		return trimmed
+	}
+	return "0"
+}
+
+// pythonKeywords are the names keyword.kwlist reserves
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true, "def": true,
+	"del": true, "elif": true, "else": true, "except": true, "finally": true, "for": true,
+	"from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// IsPythonKeyword reports whether Python reserves name, so it cannot be an identifier
+func IsPythonKeyword(name string) bool {
+	return pythonKeywords[name]
+}
+
+// PyIdentifier turns a name into a valid lowercase Python identifier: "2fast-api"
+// becomes "_2fast_api" and "class" becomes "class_"
+func PyIdentifier(s string) string {
+	identifier := Snake(s)
+	if identifier == "" {
+		return "_"
+	}
+	if unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "_" + identifier
+	}
+	if IsPythonKeyword(identifier) {
+		identifier += "_"
+	}
+	return identifier
+}
+
+// PythonTag turns a Python version into its interpreter tag: "3.12" and "3.12.1"
+// become "py312". Anything that is not a version is returned unchanged.
+func PythonTag(version string) string {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return version
+	}
+	for _, part := range parts[:2] {
+		for _, r := range part {
+			if !unicode.IsDigit(r) {
+				return version
+			}
+		}
+	}
+	return "py" + parts[0] + parts[1]
+}
+
+// LicenseHeader returns a Python comment block naming the copyright holder and the
+// SPDX license identifier, for {{ license_header("MIT", user_name) }}
+func LicenseHeader(license, holder string) string {
+	return fmt.Sprintf("# Copyright (c) %d %s\n# SPDX-License-Identifier: %s", now().Year(), holder, license)
+}
@@ -0,0 +1,237 @@
+package template
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// ignoredBlockPattern matches comments and verbatim blocks, whose content is never evaluated
+	ignoredBlockPattern = regexp.MustCompile(`(?s)\{#.*?#\}|\{%-?\s*comment\s*-?%\}.*?\{%-?\s*endcomment\s*-?%\}|\{%-?\s*verbatim\s*-?%\}.*?\{%-?\s*endverbatim\s*-?%\}`)
+
+	// tagPattern matches {{ expression }} and {% tag arguments %}
+	tagPattern = regexp.MustCompile(`(?s)\{\{-?(.*?)-?\}\}|\{%-?\s*(\w+)(.*?)-?%\}`)
+
+	stringLiteralPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`)
+	identifierPattern    = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+	variablePattern      = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*`)
+	assignmentPattern    = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\s*=[^=]`)
+)
+
+// expressionKeywords are the identifiers in expressions that are not variables
+var expressionKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true, "is": true,
+	"true": true, "false": true, "True": true, "False": true, "none": true, "None": true, "nil": true,
+}
+
+// UndefinedVariables returns the variables templateFile uses, together with the templates
+// it extends or includes, that are neither in context, nor pyinit globals, nor assigned by
+// the templates themselves. Dotted names such as project.name are followed through the
+// maps and structs of context, so a misspelled key is reported as e.g. "project.nmae".
+// Pongo2 renders undefined variables as empty strings, so this is how pyinit gets a
+// strict undefined mode.
+func (e *Engine) UndefinedVariables(templateFile string, context map[string]interface{}) ([]string, error) {
+	used := map[string]bool{}
+	assigned := map[string]bool{"forloop": true, "block": true}
+	if err := e.collectVariables(e.loader.Abs("", templateFile), used, assigned, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	return undefinedIn(used, assigned, context), nil
+}
+
+// UndefinedVariablesString is UndefinedVariables for a template string, such as a
+// manifest output path or condition, rather than a template file
+func (e *Engine) UndefinedVariablesString(source string, context map[string]interface{}) ([]string, error) {
+	used := map[string]bool{}
+	assigned := map[string]bool{"forloop": true, "block": true}
+	visited := map[string]bool{}
+	for _, reference := range templateVariables(source, used, assigned) {
+		if err := e.collectVariables(e.loader.Abs("", reference), used, assigned, visited); err != nil {
+			return nil, err
+		}
+	}
+
+	return undefinedIn(used, assigned, context), nil
+}
+
+// undefinedIn returns the used variables, sorted, that neither context nor the globals
+// define and the templates do not assign
+func undefinedIn(used, assigned map[string]bool, context map[string]interface{}) []string {
+	globals := templateGlobals()
+	found := map[string]bool{}
+	for variable := range used {
+		parts := strings.Split(variable, ".")
+		if _, ok := globals[parts[0]]; ok || assigned[parts[0]] {
+			// The values of globals and template variables are not known here
+			continue
+		}
+		value, ok := context[parts[0]]
+		if !ok {
+			found[parts[0]] = true
+			continue
+		}
+		if missing := missingPart(value, parts[1:]); missing >= 0 {
+			found[strings.Join(parts[:missing+2], ".")] = true
+		}
+	}
+
+	undefined := make([]string, 0, len(found))
+	for name := range found {
+		undefined = append(undefined, name)
+	}
+	sort.Strings(undefined)
+
+	return undefined
+}
+
+// missingPart looks parts up one after another, starting from value, the way pongo2
+// resolves attributes, and returns the index of the first one that does not exist, or -1
+// when they all do. Lookups past a method or a nil value cannot be checked and pass.
+func missingPart(value interface{}, parts []string) int {
+	for i, part := range parts {
+		current := reflect.ValueOf(value)
+		if !current.IsValid() || current.MethodByName(part).IsValid() {
+			return -1
+		}
+		if current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				return -1
+			}
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Map:
+			key := reflect.ValueOf(part)
+			if !key.Type().AssignableTo(current.Type().Key()) {
+				return i
+			}
+			current = current.MapIndex(key)
+		case reflect.Struct:
+			current = current.FieldByName(part)
+		default:
+			return i
+		}
+
+		if !current.IsValid() {
+			return i
+		}
+		if !current.CanInterface() {
+			return -1
+		}
+		value = current.Interface()
+	}
+	return -1
+}
+
+// collectVariables adds the variables the resolved template name reads and assigns, then
+// does the same for every template it extends, includes or imports
+func (e *Engine) collectVariables(name string, used, assigned, visited map[string]bool) error {
+	if visited[name] {
+		return nil
+	}
+	visited[name] = true
+
+	reader, err := e.loader.Get(name)
+	if err != nil {
+		return fmt.Errorf("failed to load template %s: %w", name, err)
+	}
+	source, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to load template %s: %w", name, err)
+	}
+
+	for _, reference := range templateVariables(string(source), used, assigned) {
+		if err := e.collectVariables(e.loader.Abs(name, reference), used, assigned, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateVariables scans template source for the variables its expressions read and
+// the ones its tags assign, and returns the templates it extends, includes or imports
+func templateVariables(source string, used, assigned map[string]bool) (references []string) {
+	source = ignoredBlockPattern.ReplaceAllString(source, "")
+	for _, match := range tagPattern.FindAllStringSubmatch(source, -1) {
+		if match[2] == "" {
+			addExpressionVariables(used, match[1])
+			continue
+		}
+
+		tag, args := match[2], stringLiteralPattern.ReplaceAllString(match[3], `""`)
+		if tag == "extends" || tag == "include" || tag == "import" {
+			// Only literal template names can be followed
+			if loc := stringLiteralPattern.FindStringIndex(match[3]); loc != nil && strings.TrimSpace(match[3][:loc[0]]) == "" {
+				references = append(references, match[3][loc[0]+1:loc[1]-1])
+			}
+		}
+
+		switch tag {
+		case "if", "elif":
+			addExpressionVariables(used, args)
+		case "for":
+			// for key, value in items [reversed] [sorted]
+			targets, items, found := strings.Cut(args, " in ")
+			if !found {
+				continue
+			}
+			for _, name := range identifierPattern.FindAllString(targets, -1) {
+				assigned[name] = true
+			}
+			items = strings.TrimSpace(items)
+			items = strings.TrimSuffix(strings.TrimSuffix(items, " sorted"), " reversed")
+			addExpressionVariables(used, items)
+		case "set", "with", "include":
+			// set name = value; with name=value ...; with value as name; include "x" with name=value
+			if before, name, found := strings.Cut(args, " as "); found && tag == "with" {
+				assigned[strings.TrimSpace(name)] = true
+				addExpressionVariables(used, before)
+				continue
+			}
+			for _, assignment := range assignmentPattern.FindAllStringSubmatch(args, -1) {
+				assigned[assignment[1]] = true
+			}
+			rest := assignmentPattern.ReplaceAllStringFunc(args, func(s string) string { return s[len(s)-1:] })
+			rest = strings.NewReplacer(" with ", " ", " only", " ", " if_exists", " ").Replace(" " + rest + " ")
+			addExpressionVariables(used, rest)
+		case "macro", "import":
+			// Macro names, their parameters and imported names are all assigned
+			for _, name := range identifierPattern.FindAllString(args, -1) {
+				assigned[name] = true
+			}
+		}
+	}
+
+	return references
+}
+
+// addExpressionVariables adds the variables an expression reads, with the attributes
+// they are accessed by, such as "project.name": names that are not keywords, attributes
+// of literals or filter names after a pipe
+func addExpressionVariables(used map[string]bool, expression string) {
+	expression = stringLiteralPattern.ReplaceAllString(expression, `""`)
+
+	for _, loc := range variablePattern.FindAllStringIndex(expression, -1) {
+		name := expression[loc[0]:loc[1]]
+		if expressionKeywords[name] {
+			continue
+		}
+
+		prev := strings.TrimRight(expression[:loc[0]], " \t\n")
+		if strings.HasSuffix(prev, ".") || strings.HasSuffix(prev, "|") {
+			continue
+		}
+		// Skip the rest of numbers such as 1e3
+		if loc[0] > 0 && expression[loc[0]-1] >= '0' && expression[loc[0]-1] <= '9' {
+			continue
+		}
+
+		used[name] = true
+	}
+}
@@ -1,35 +1,45 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Pradyothsp/pyinit/internal/version"
 )
 
 // Banner handles ASCII banner display
 type Banner struct {
-	config *Config
+	config  *Config
+	loadErr error // Why the config file could not be loaded, when config holds the defaults instead
 }
 
 // NewBanner creates a new banner instance
 func NewBanner() (*Banner, error) {
 	config, err := LoadConfig()
-	if err != nil {
+	var loadErr error
+	switch {
+	case errors.Is(err, ErrNoConfig):
 		// If config doesn't exist, create default
 		config = DefaultConfig()
 		if err := config.Save(); err != nil {
 			// If we can't save config, continue with defaults
-			fmt.Printf("Warning: Could not save config to ~/.pyinitrc: %v\n", err)
+			fmt.Printf("Warning: Could not save config to %s: %v\n", GetConfigPath(), err)
 		}
+	case err != nil:
+		// Keep an invalid config file for the user to fix, and continue with defaults
+		fmt.Printf("Warning: %v\n", err)
+		config = DefaultConfig()
+		loadErr = err
 	}
 
 	return &Banner{
-		config: config,
+		config:  config,
+		loadErr: loadErr,
 	}, nil
 }
 
 // Show displays the banner if enabled in config
 func (b *Banner) Show() {
-	if !b.config.ShowBanner {
+	if !b.config.UI.ShowBanner {
 		return
 	}
 
@@ -56,17 +66,26 @@ func (b *Banner) generateBanner() string {
 
 // IsEnabled returns whether the banner is enabled
 func (b *Banner) IsEnabled() bool {
-	return b.config.ShowBanner
+	return b.config.UI.ShowBanner
 }
 
 // Enable enables the banner and saves config
 func (b *Banner) Enable() error {
-	b.config.ShowBanner = true
-	return b.config.Save()
+	return b.save(true)
 }
 
 // Disable disables the banner and saves config
 func (b *Banner) Disable() error {
-	b.config.ShowBanner = false
+	return b.save(false)
+}
+
+// save stores whether the banner is shown. Defaults standing in for a config file that
+// did not load are never saved, as they would overwrite every setting in the file.
+func (b *Banner) save(enabled bool) error {
+	if b.loadErr != nil {
+		return b.loadErr
+	}
+
+	b.config.UI.ShowBanner = enabled
 	return b.config.Save()
 }
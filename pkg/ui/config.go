@@ -1,149 +1,370 @@
 package ui
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/BurntSushi/toml"
 )
 
-// Config holds UI configuration
+// Config holds the user configuration, stored as TOML in $XDG_CONFIG_HOME/pyinit/config.toml
 type Config struct {
-	ShowBanner bool `config:"show_banner"`
+	UI        UIConfig        `toml:"ui"`
+	Defaults  DefaultsConfig  `toml:"defaults"`
+	Templates TemplatesConfig `toml:"templates"`
+	Setup     SetupConfig     `toml:"setup"`
+}
+
+// UIConfig controls how pyinit looks
+type UIConfig struct {
+	ShowBanner bool `toml:"show_banner"`
 	// Future extensions can be added here
-	// EnableAnimations bool `config:"enable_animations"`
-	// CurrentTheme string `config:"current_theme"`
+	// EnableAnimations bool `toml:"enable_animations"`
+	// CurrentTheme string `toml:"current_theme"`
+}
+
+// DefaultsConfig holds the answers the prompts offer by default
+type DefaultsConfig struct {
+	Author        string `toml:"author"`
+	Email         string `toml:"email"`
+	PythonVersion string `toml:"python_version"`
+	ProjectType   string `toml:"project_type"`
+	RememberLast  bool   `toml:"remember_last"` // Store each run's answers as the defaults
+}
+
+// TemplatesConfig locates the user's templates
+type TemplatesConfig struct {
+	Dir string `toml:"dir"` // Directory whose templates override the built-in ones
 }
 
+// SetupConfig controls what happens after the project files are written
+type SetupConfig struct {
+	Environment string `toml:"environment" choices:"ask,always,never"` // Whether to set up the environment
+}
+
+// ErrNoConfig is returned by LoadConfig when there is no config file yet
+var ErrNoConfig = errors.New("config file does not exist")
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
-		ShowBanner: true,
+		UI:    UIConfig{ShowBanner: true},
+		Setup: SetupConfig{Environment: "ask"},
 	}
 }
 
-// getConfigPath returns the path to the .pyinitrc file
+// getConfigPath returns the path to config.toml in $XDG_CONFIG_HOME/pyinit, or
+// ~/.config/pyinit when XDG_CONFIG_HOME is not set
 func getConfigPath() (string, error) {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "pyinit", "config.toml"), nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	return filepath.Join(homeDir, ".pyinitrc"), nil
+	return filepath.Join(homeDir, ".config", "pyinit", "config.toml"), nil
 }
 
-// LoadConfig loads configuration from ~/.pyinitrc
+// LoadConfig loads the config file, migrating ~/.pyinitrc the first time. It returns
+// ErrNoConfig when neither exists, and a *SchemaError when the file has unknown keys
+// or invalid values.
 func LoadConfig() (*Config, error) {
 	configPath, err := getConfigPath()
 	if err != nil {
 		return nil, err
 	}
 
-	// If config doesn't exist, return error so caller can create default
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("config file does not exist")
+		migrated, err := migrateLegacyConfig()
+		if err != nil {
+			return nil, err
+		}
+		if !migrated {
+			return nil, ErrNoConfig
+		}
 	}
 
-	file, err := os.Open(configPath)
+	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open config file: %w", err)
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			fmt.Printf("Warning: Failed to close config file: %v\n", err)
-		}
-	}(file)
 
 	config := DefaultConfig()
-	scanner := bufio.NewScanner(file)
+	meta, err := toml.Decode(string(data), config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", configPath, err)
+	}
+
+	if err := config.validate(configPath, data, meta); err != nil {
+		return nil, err
+	}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+	config.Templates.Dir = expandHome(config.Templates.Dir)
+	return config, nil
+}
 
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
+// SchemaProblem is a key in the config file that is unknown or has an invalid value
+type SchemaProblem struct {
+	Line    int // Line number, starting at 1, or 0 when the line is not known
+	Key     string
+	Message string
+}
+
+// SchemaError lists every problem found in a config file
+type SchemaError struct {
+	Path     string
+	Problems []SchemaProblem
+}
+
+func (e *SchemaError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		location := e.Path
+		if problem.Line > 0 {
+			location = fmt.Sprintf("%s:%d", e.Path, problem.Line)
+		}
+		messages[i] = fmt.Sprintf("%s: %s", location, problem.Message)
+	}
+	return "invalid config file:\n  " + strings.Join(messages, "\n  ")
+}
+
+// validate reports keys the schema does not define and values outside a setting's choices
+func (c *Config) validate(path string, data []byte, meta toml.MetaData) error {
+	lines := keyLines(splitLines(data))
+	var problems []SchemaProblem
+
+	for _, key := range meta.Undecoded() {
+		// Report the keys of unknown tables, not the tables themselves
+		if meta.Type(key...) == "Hash" {
 			continue
 		}
+		problems = append(problems, SchemaProblem{
+			Line:    lines[key.String()],
+			Key:     key.String(),
+			Message: fmt.Sprintf("unknown key %q (known keys: %s)", key.String(), strings.Join(Keys(), ", ")),
+		})
+	}
 
-		// Parse key=value pairs
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
+	for _, key := range Keys() {
+		if !meta.IsDefined(strings.Split(key, ".")...) {
 			continue
 		}
+		if err := c.checkChoices(key); err != nil {
+			problems = append(problems, SchemaProblem{Line: lines[key], Key: key, Message: err.Error()})
+		}
+	}
 
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
+	if len(problems) > 0 {
+		return &SchemaError{Path: path, Problems: problems}
+	}
+	return nil
+}
 
-		// Remove quotes if present
-		value = strings.Trim(value, "\"'")
+// setting is one value of the config, named by its dotted key such as "ui.show_banner"
+type setting struct {
+	key     string
+	value   reflect.Value
+	choices []string
+}
 
-		switch key {
-		case "show_banner":
-			if boolVal, err := strconv.ParseBool(value); err == nil {
-				config.ShowBanner = boolVal
+// settings lists the values of c in file order
+func (c *Config) settings() []setting {
+	var all []setting
+	sections := reflect.ValueOf(c).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		sectionName := sections.Type().Field(i).Tag.Get("toml")
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Type().Field(j)
+			var choices []string
+			if tag := field.Tag.Get("choices"); tag != "" {
+				choices = strings.Split(tag, ",")
 			}
-			// Future config options can be added here
-			// case "enable_animations":
-			//     if boolVal, err := strconv.ParseBool(value); err == nil {
-			//         config.EnableAnimations = boolVal
-			//     }
-			// case "current_theme":
-			//     config.CurrentTheme = value
+			all = append(all, setting{
+				key:     sectionName + "." + field.Tag.Get("toml"),
+				value:   section.Field(j),
+				choices: choices,
+			})
 		}
 	}
+	return all
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+// Keys returns the dotted names of the settings, in the order they appear in the config file
+func Keys() []string {
+	var keys []string
+	for _, s := range DefaultConfig().settings() {
+		keys = append(keys, s.key)
 	}
+	return keys
+}
 
-	return config, nil
+// setting returns the setting named key, accepting the names ~/.pyinitrc used
+func (c *Config) setting(key string) (setting, error) {
+	if renamed, ok := legacyKeys[key]; ok {
+		key = renamed
+	}
+	for _, s := range c.settings() {
+		if s.key == key {
+			return s, nil
+		}
+	}
+	return setting{}, fmt.Errorf("unknown setting %q (use one of: %s)", key, strings.Join(Keys(), ", "))
 }
 
-// Save saves the configuration to ~/.pyinitrc
-func (c *Config) Save() error {
-	configPath, err := getConfigPath()
+// ResolveKey returns the dotted name of a setting, translating the names ~/.pyinitrc used
+func ResolveKey(key string) (string, error) {
+	s, err := DefaultConfig().setting(key)
 	if err != nil {
+		return "", err
+	}
+	return s.key, nil
+}
+
+// checkChoices reports a setting whose value is not one of its choices
+func (c *Config) checkChoices(key string) error {
+	s, err := c.setting(key)
+	if err != nil || len(s.choices) == 0 {
 		return err
 	}
+	if !slices.Contains(s.choices, s.value.String()) {
+		return fmt.Errorf("%s must be one of: %s (got %q)", s.key, strings.Join(s.choices, ", "), s.value.String())
+	}
+	return nil
+}
+
+// Get returns a setting the way it is shown to users
+func (c *Config) Get(key string) (string, error) {
+	s, err := c.setting(key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(s.value.Interface()), nil
+}
 
-	file, err := os.Create(configPath)
+// Set parses value and assigns it to the setting named key
+func (c *Config) Set(key, value string) error {
+	s, err := c.setting(key)
 	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
+		return err
 	}
-	defer func(file *os.File) {
-		err := file.Close()
+
+	switch s.value.Kind() {
+	case reflect.Bool:
+		boolVal, err := strconv.ParseBool(value)
 		if err != nil {
-			fmt.Printf("Warning: Failed to close config file: %v\n", err)
+			return fmt.Errorf("%s must be true or false", s.key)
+		}
+		s.value.SetBool(boolVal)
+	default:
+		if len(s.choices) > 0 && !slices.Contains(s.choices, value) {
+			return fmt.Errorf("%s must be one of: %s", s.key, strings.Join(s.choices, ", "))
 		}
-	}(file)
+		s.value.SetString(value)
+	}
+	return nil
+}
+
+// Unset restores the setting named key to its default
+func (c *Config) Unset(key string) error {
+	s, err := c.setting(key)
+	if err != nil {
+		return err
+	}
 
-	w := bufio.NewWriter(file)
+	defaults, _ := DefaultConfig().setting(key)
+	s.value.Set(defaults.value)
+	return nil
+}
 
-	// Write header comment
-	_, _ = fmt.Fprintln(w, "# pyinit configuration file")
-	_, _ = fmt.Fprintln(w, "# This file configures the behavior of the pyinit CLI tool")
-	_, _ = fmt.Fprintln(w, "")
+// Save writes the configuration to the config file. An existing file is updated in
+// place, so comments and the order of keys are kept.
+func (c *Config) Save() error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
+	}
 
-	// Write current config
-	_, _ = fmt.Fprintf(w, "# Show ASCII banner on startup (true/false)\n")
-	_, _ = fmt.Fprintf(w, "show_banner=%t\n", c.ShowBanner)
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		data, err = []byte(configTemplate), nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
 
-	// Placeholder for future config options
-	_, _ = fmt.Fprintln(w, "")
-	_, _ = fmt.Fprintln(w, "# Future configuration options will appear here")
-	_, _ = fmt.Fprintln(w, "# enable_animations=true")
-	_, _ = fmt.Fprintln(w, "# current_theme=ocean")
+	lines := splitLines(data)
+	defaults := DefaultConfig()
+	for _, s := range c.settings() {
+		value, err := tomlValue(s.value.Interface())
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", s.key, err)
+		}
+		def, _ := defaults.setting(s.key)
+		isDefault := reflect.DeepEqual(s.value.Interface(), def.value.Interface())
+		lines = setKey(lines, s.key, value, isDefault)
+	}
 
-	if err := w.Flush(); err != nil {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
+// configTemplate is the file Save starts from when there is none, documenting every setting
+const configTemplate = `# pyinit configuration file
+# This file configures the behavior of the pyinit CLI tool
+
+[ui]
+# Show ASCII banner on startup
+show_banner = true
+
+[defaults]
+# Answers the prompts offer by default. Without author and email,
+# git config user.name and user.email are offered.
+# author = "Jane Doe"
+# email = "jane@example.com"
+# python_version = "3.13"
+# project_type = "basic"
+
+# Replace the defaults above with the answers of each run
+remember_last = false
+
+[templates]
+# Directory whose templates override the built-in ones (e.g. core/gitignore.j2)
+# dir = "~/.config/pyinit/templates"
+
+[setup]
+# Set up the development environment after generation: ask, always or never
+environment = "ask"
+`
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
 // GetConfigPath returns the config file path for display
 func GetConfigPath() string {
 	path, _ := getConfigPath()
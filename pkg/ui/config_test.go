@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// useTempConfig points the config file and ~/.pyinitrc at temporary directories and
+// returns the config file path
+func useTempConfig(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	return GetConfigPath()
+}
+
+// writeConfig writes content to the config file
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := useTempConfig(t)
+
+	if _, err := LoadConfig(); !errors.Is(err, ErrNoConfig) {
+		t.Fatalf("LoadConfig() error = %v, want ErrNoConfig", err)
+	}
+
+	writeConfig(t, path, `[ui]
+show_banner = false
+
+[defaults]
+author = "Jane Doe"
+
+[templates]
+dir = "~/templates"
+`)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	home, _ := os.UserHomeDir()
+	want := DefaultConfig()
+	want.UI.ShowBanner = false
+	want.Defaults.Author = "Jane Doe"
+	want.Templates.Dir = filepath.Join(home, "templates")
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadConfig() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadConfig_SchemaErrors(t *testing.T) {
+	path := useTempConfig(t)
+	writeConfig(t, path, `[ui]
+show_banner = true
+colour = "blue"
+
+[setup]
+environment = "sometimes"
+
+[plugins]
+enabled = true
+`)
+
+	_, err := LoadConfig()
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("LoadConfig() error = %v, want a SchemaError", err)
+	}
+
+	lines := map[string]int{}
+	for _, problem := range schemaErr.Problems {
+		lines[problem.Key] = problem.Line
+	}
+	want := map[string]int{"ui.colour": 3, "setup.environment": 6, "plugins.enabled": 9}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("Problems at %v, want %v", lines, want)
+	}
+	if !strings.Contains(err.Error(), path+`:3: unknown key "ui.colour"`) {
+		t.Errorf("Error does not name the file and line:\n%v", err)
+	}
+
+	// Values of the wrong type are reported by the TOML decoder, with their line
+	writeConfig(t, path, "[ui]\nshow_banner = \"yes\"\n")
+	if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("LoadConfig() error = %v, want one naming line 2", err)
+	}
+}
+
+func TestSave_KeepsComments(t *testing.T) {
+	path := useTempConfig(t)
+	writeConfig(t, path, `# My settings
+[ui]
+show_banner = true # shown on every run
+
+[defaults]
+# Work identity
+email = "jane@work.example.com"
+`)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	cfg.UI.ShowBanner = false
+	cfg.Defaults.Author = "Jane Doe"
+	cfg.Setup.Environment = "always"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	want := `# My settings
+[ui]
+show_banner = false # shown on every run
+
+[defaults]
+# Work identity
+email = "jane@work.example.com"
+author = "Jane Doe"
+
+[setup]
+environment = "always"
+`
+	if string(content) != want {
+		t.Errorf("Saved config =\n%s\nwant\n%s", content, want)
+	}
+
+	saved, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() after Save error = %v", err)
+	}
+	if !reflect.DeepEqual(saved, cfg) {
+		t.Errorf("LoadConfig() after Save = %+v, want %+v", saved, cfg)
+	}
+}
+
+func TestSave_NewFileIsDocumented(t *testing.T) {
+	path := useTempConfig(t)
+
+	cfg := DefaultConfig()
+	cfg.Defaults.Email = "jane@example.com"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	for _, want := range []string{"[ui]\n", "email = \"jane@example.com\"\n", "# author = \"Jane Doe\"\n", "environment = \"ask\"\n"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("New config file is missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestMigrateLegacyConfig(t *testing.T) {
+	path := useTempConfig(t)
+	home, _ := os.UserHomeDir()
+	legacy := "# pyinit configuration file\nshow_banner=false\ntemplate_dir=/srv/templates\ndefault_author=\"Jane Doe\"\nremember_last=yes\nunknown=1\n"
+	if err := os.WriteFile(filepath.Join(home, ".pyinitrc"), []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write legacy config: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := DefaultConfig()
+	want.UI.ShowBanner = false
+	want.Templates.Dir = "/srv/templates"
+	want.Defaults.Author = "Jane Doe"
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Migrated config = %+v, want %+v", cfg, want)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Config file was not written: %v", err)
+	}
+
+	// Later changes to the legacy file are not migrated again
+	if err := os.WriteFile(filepath.Join(home, ".pyinitrc"), []byte("show_banner=true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write legacy config: %v", err)
+	}
+	if cfg, err := LoadConfig(); err != nil || cfg.UI.ShowBanner {
+		t.Errorf("LoadConfig() = %+v, %v, want the migrated settings kept", cfg, err)
+	}
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// legacyKeys maps the keys of ~/.pyinitrc to the settings that replaced them
+var legacyKeys = map[string]string{
+	"show_banner":            "ui.show_banner",
+	"template_dir":           "templates.dir",
+	"default_author":         "defaults.author",
+	"default_email":          "defaults.email",
+	"default_python_version": "defaults.python_version",
+	"default_project_type":   "defaults.project_type",
+	"remember_last":          "defaults.remember_last",
+}
+
+// getLegacyConfigPath returns the path to the key=value file earlier versions used
+func getLegacyConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, ".pyinitrc"), nil
+}
+
+// migrateLegacyConfig writes the settings of ~/.pyinitrc to the config file, reporting
+// whether there was one. It only runs while the config file does not exist, so the
+// legacy file is migrated once and then left alone.
+func migrateLegacyConfig() (bool, error) {
+	legacyPath, err := getLegacyConfigPath()
+	if err != nil {
+		return false, err
+	}
+
+	file, err := os.Open(legacyPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", legacyPath, err)
+	}
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
+			fmt.Printf("Warning: Failed to close config file: %v\n", err)
+		}
+	}(file)
+
+	config := DefaultConfig()
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Parse key=value pairs
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		// Remove quotes if present
+		value = strings.Trim(strings.TrimSpace(value), "\"'")
+
+		// The legacy format ignored unknown keys and invalid values, so migration does too
+		if newKey, ok := legacyKeys[strings.TrimSpace(key)]; ok {
+			_ = config.Set(newKey, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", legacyPath, err)
+	}
+
+	if err := config.Save(); err != nil {
+		return false, fmt.Errorf("failed to migrate %s: %w", legacyPath, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Migrated settings from %s to %s\n", legacyPath, GetConfigPath())
+	return true, nil
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Lines of a config file that Save and the schema check recognise. Keys are matched by
+// name only; values spanning several lines are not supported.
+var (
+	sectionPattern      = regexp.MustCompile(`^\s*\[\s*([A-Za-z0-9_.-]+)\s*\]`)
+	keyPattern          = regexp.MustCompile(`^(\s*)([A-Za-z0-9_.-]+)\s*=`)
+	commentedKeyPattern = regexp.MustCompile(`^\s*#\s*([A-Za-z0-9_.-]+)\s*=`)
+)
+
+// splitLines splits a config file into lines that strings.Join(lines, "\n") puts back together
+func splitLines(data []byte) []string {
+	return strings.Split(string(data), "\n")
+}
+
+// fullKey joins the section a line is in and the key it sets
+func fullKey(section, name string) string {
+	if section == "" {
+		return name
+	}
+	return section + "." + name
+}
+
+// keyLines maps the dotted keys set in a config file to their line numbers, starting at 1
+func keyLines(lines []string) map[string]int {
+	found := map[string]int{}
+	section := ""
+	for i, line := range lines {
+		if match := sectionPattern.FindStringSubmatch(line); match != nil {
+			section = match[1]
+			continue
+		}
+		if match := keyPattern.FindStringSubmatch(line); match != nil {
+			key := fullKey(section, match[2])
+			if _, seen := found[key]; !seen {
+				found[key] = i + 1
+			}
+		}
+	}
+	return found
+}
+
+// setKey sets a dotted key such as "ui.show_banner" to value, an encoded TOML value, in
+// the lines of a config file. The line setting the key is replaced; otherwise the
+// commented-out example of the key is, or the key is added to its section. When
+// onlyIfPresent is set, a key the file does not set is left out.
+func setKey(lines []string, key, value string, onlyIfPresent bool) []string {
+	section, name := key[:strings.LastIndex(key, ".")], key[strings.LastIndex(key, ".")+1:]
+
+	current := ""
+	header, lastKey, commented := -1, -1, -1
+	for i, line := range lines {
+		if match := sectionPattern.FindStringSubmatch(line); match != nil {
+			current = match[1]
+			if current == section {
+				header, lastKey = i, i
+			}
+			continue
+		}
+		if match := keyPattern.FindStringSubmatch(line); match != nil {
+			if fullKey(current, match[2]) == key {
+				lines[i] = match[1] + match[2] + " = " + value + trailingComment(line[len(match[0]):])
+				return lines
+			}
+			if current == section {
+				lastKey = i
+			}
+			continue
+		}
+		if match := commentedKeyPattern.FindStringSubmatch(line); match != nil && current == section && match[1] == name && commented < 0 {
+			commented = i
+		}
+	}
+
+	if onlyIfPresent {
+		return lines
+	}
+
+	entry := name + " = " + value
+	switch {
+	case commented >= 0:
+		lines[commented] = entry
+		return lines
+	case header >= 0:
+		return append(lines[:lastKey+1], append([]string{entry}, lines[lastKey+1:]...)...)
+	}
+
+	// Add the section at the end, before the final newline
+	end := len(lines)
+	if end > 0 && lines[end-1] == "" {
+		end--
+	}
+	added := []string{"", "[" + section + "]", entry}
+	return append(lines[:end], append(added, lines[end:]...)...)
+}
+
+// trailingComment returns the comment after the value of a key line, with the spaces
+// before it, or "" when there is none. rest is the part of the line after the "=".
+func trailingComment(rest string) string {
+	end := len(rest)
+	inString := byte(0)
+	for i := 0; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case inString != 0 && c == '\\' && inString == '"':
+			i++
+		case inString != 0 && c == inString:
+			inString = 0
+		case inString == 0 && (c == '"' || c == '\''):
+			inString = c
+		case inString == 0 && c == '#':
+			end = i
+			i = len(rest)
+		}
+	}
+	if end == len(rest) {
+		return ""
+	}
+
+	value := strings.TrimRight(rest[:end], " \t")
+	return rest[len(value):]
+}
+
+// tomlValue encodes a setting's value the way it is written in the config file
+func tomlValue(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]interface{}{"v": value}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimPrefix(buf.String(), "v = ")), nil
+}
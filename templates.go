@@ -2,5 +2,5 @@ package pyinit
 
 import "embed"
 
-//go:embed templates/*
+//go:embed all:templates
 var EmbeddedTemplates embed.FS
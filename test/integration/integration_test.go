@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -195,6 +196,323 @@ func TestFastAPIProjectGeneration(t *testing.T) {
 	}
 }
 
+// Integration test for Flask project generation
+func TestFlaskProjectGeneration(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := &config.ProjectConfig{
+		UserName:           "Flask Test",
+		Email:              "flask@test.com",
+		ProjectName:        "test-flask-project",
+		ProjectDescription: "Flask integration test project",
+		ProjectType:        "web",
+		WebFramework:       "flask",
+		ProjectPath:        filepath.Join(tempDir, "test-flask-project"),
+		MainDirName:        "test_flask_project",
+		PythonVersion:      "3.12",
+	}
+
+	gen := generator.New()
+	if err := gen.GenerateProject(cfg); err != nil {
+		t.Fatalf("Flask project generation failed: %v", err)
+	}
+
+	expectedFlaskFiles := []string{
+		"README.md",
+		"pyproject.toml",
+		filepath.Join(cfg.MainDirName, "__init__.py"),
+		filepath.Join(cfg.MainDirName, "config.py"),
+		filepath.Join(cfg.MainDirName, "wsgi.py"),
+		filepath.Join(cfg.MainDirName, "api", "__init__.py"),
+		filepath.Join(cfg.MainDirName, "api", "routes.py"),
+		filepath.Join("tests", "__init__.py"),
+		filepath.Join("tests", "conftest.py"),
+		filepath.Join("tests", "test_api.py"),
+	}
+
+	for _, file := range expectedFlaskFiles {
+		if _, err := os.Stat(filepath.Join(cfg.ProjectPath, file)); os.IsNotExist(err) {
+			t.Errorf("Expected Flask file %s does not exist", file)
+		}
+	}
+
+	pyproject, err := os.ReadFile(filepath.Join(cfg.ProjectPath, "pyproject.toml"))
+	if err != nil {
+		t.Fatalf("Failed to read pyproject.toml: %v", err)
+	}
+	if !strings.Contains(string(pyproject), `serve = "test_flask_project.wsgi:run_server"`) {
+		t.Errorf("pyproject.toml is missing the serve entry point:\n%s", pyproject)
+	}
+}
+
+// Integration test for Django project generation
+func TestDjangoProjectGeneration(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := &config.ProjectConfig{
+		UserName:           "Django Test",
+		Email:              "django@test.com",
+		ProjectName:        "test-django-project",
+		ProjectDescription: "Django integration test project",
+		ProjectType:        "web",
+		WebFramework:       "django",
+		ProjectPath:        filepath.Join(tempDir, "test-django-project"),
+		MainDirName:        "test_django_project",
+		PythonVersion:      "3.12",
+	}
+
+	gen := generator.New()
+	if err := gen.GenerateProject(cfg); err != nil {
+		t.Fatalf("Django project generation failed: %v", err)
+	}
+
+	expectedDjangoFiles := []string{
+		"README.md",
+		"pyproject.toml",
+		"manage.py",
+		filepath.Join("config", "urls.py"),
+		filepath.Join("config", "wsgi.py"),
+		filepath.Join("config", "asgi.py"),
+		filepath.Join("config", "settings", "base.py"),
+		filepath.Join("config", "settings", "dev.py"),
+		filepath.Join("config", "settings", "prod.py"),
+		filepath.Join(cfg.MainDirName, "apps.py"),
+		filepath.Join(cfg.MainDirName, "views.py"),
+		filepath.Join(cfg.MainDirName, "urls.py"),
+		filepath.Join(cfg.MainDirName, "migrations", "__init__.py"),
+		filepath.Join("tests", "test_views.py"),
+	}
+
+	for _, file := range expectedDjangoFiles {
+		if _, err := os.Stat(filepath.Join(cfg.ProjectPath, file)); os.IsNotExist(err) {
+			t.Errorf("Expected Django file %s does not exist", file)
+		}
+	}
+
+	// The manifest marks manage.py executable
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(cfg.ProjectPath, "manage.py"))
+		if err != nil {
+			t.Fatalf("Failed to stat manage.py: %v", err)
+		}
+		if info.Mode().Perm() != 0755 {
+			t.Errorf("manage.py mode = %o, want 755", info.Mode().Perm())
+		}
+	}
+
+	apps, err := os.ReadFile(filepath.Join(cfg.ProjectPath, cfg.MainDirName, "apps.py"))
+	if err != nil {
+		t.Fatalf("Failed to read apps.py: %v", err)
+	}
+	if !strings.Contains(string(apps), "class TestDjangoProjectConfig(AppConfig):") {
+		t.Errorf("apps.py does not define the app config class:\n%s", apps)
+	}
+
+	pyproject, err := os.ReadFile(filepath.Join(cfg.ProjectPath, "pyproject.toml"))
+	if err != nil {
+		t.Fatalf("Failed to read pyproject.toml: %v", err)
+	}
+	for _, want := range []string{`DJANGO_SETTINGS_MODULE = "config.settings.dev"`, `"**/migrations"`, `"migrations"`} {
+		if !strings.Contains(string(pyproject), want) {
+			t.Errorf("pyproject.toml is missing %s", want)
+		}
+	}
+}
+
+func TestDjangoProjectRejectsConfigMainDir(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := &config.ProjectConfig{
+		UserName:      "Django Test",
+		Email:         "django@test.com",
+		ProjectName:   "config",
+		ProjectType:   "web",
+		WebFramework:  "django",
+		ProjectPath:   filepath.Join(tempDir, "config"),
+		MainDirName:   "config",
+		PythonVersion: "3.12",
+	}
+
+	if err := generator.New().GenerateProject(cfg); err == nil {
+		t.Fatal("Expected an error when the app would shadow the config package")
+	}
+	if _, err := os.Stat(cfg.ProjectPath); !os.IsNotExist(err) {
+		t.Error("Failed generation left the project directory behind")
+	}
+}
+
+// Integration test for CLI project generation with each supported framework
+func TestCLIProjectGeneration(t *testing.T) {
+	for _, framework := range config.CLIFrameworks() {
+		t.Run(framework, func(t *testing.T) {
+			tempDir := t.TempDir()
+
+			cfg := &config.ProjectConfig{
+				UserName:           "CLI Test",
+				Email:              "cli@test.com",
+				ProjectName:        "test-cli-project",
+				ProjectDescription: "CLI integration test project",
+				ProjectType:        "cli",
+				CLIFramework:       framework,
+				ProjectPath:        filepath.Join(tempDir, "test-cli-project"),
+				MainDirName:        "test_cli_project",
+				PythonVersion:      "3.12",
+			}
+
+			gen := generator.New()
+			if err := gen.GenerateProject(cfg); err != nil {
+				t.Fatalf("CLI project generation failed: %v", err)
+			}
+
+			expectedFiles := []string{
+				"README.md",
+				"pyproject.toml",
+				filepath.Join(cfg.MainDirName, "__init__.py"),
+				filepath.Join(cfg.MainDirName, "__main__.py"),
+				filepath.Join(cfg.MainDirName, "cli.py"),
+				filepath.Join(cfg.MainDirName, "commands", "__init__.py"),
+				filepath.Join(cfg.MainDirName, "commands", "hello.py"),
+				filepath.Join("tests", "__init__.py"),
+				filepath.Join("tests", "test_cli.py"),
+			}
+
+			for _, file := range expectedFiles {
+				if _, err := os.Stat(filepath.Join(cfg.ProjectPath, file)); os.IsNotExist(err) {
+					t.Errorf("Expected CLI file %s does not exist", file)
+				}
+			}
+
+			pyproject, err := os.ReadFile(filepath.Join(cfg.ProjectPath, "pyproject.toml"))
+			if err != nil {
+				t.Fatalf("Failed to read pyproject.toml: %v", err)
+			}
+			if !strings.Contains(string(pyproject), `test-cli-project = "test_cli_project.cli:`) {
+				t.Errorf("pyproject.toml is missing the console entry point:\n%s", pyproject)
+			}
+			if !strings.Contains(string(pyproject), framework) {
+				t.Errorf("pyproject.toml does not depend on %s", framework)
+			}
+		})
+	}
+}
+
+func TestLibraryProjectGeneration(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := &config.ProjectConfig{
+		UserName:           "Library Test",
+		Email:              "library@test.com",
+		ProjectName:        "test-library",
+		ProjectDescription: "Library integration test project",
+		ProjectType:        "library",
+		ProjectPath:        filepath.Join(tempDir, "test-library"),
+		MainDirName:        "test_library",
+		PythonVersion:      "3.12",
+	}
+
+	gen := generator.New()
+	if err := gen.GenerateProject(cfg); err != nil {
+		t.Fatalf("Library project generation failed: %v", err)
+	}
+
+	expectedFiles := []string{
+		"README.md",
+		"CHANGELOG.md",
+		"pyproject.toml",
+		filepath.Join("src", cfg.MainDirName, "__init__.py"),
+		filepath.Join("src", cfg.MainDirName, "core.py"),
+		filepath.Join("src", cfg.MainDirName, "py.typed"),
+		filepath.Join("tests", "__init__.py"),
+		filepath.Join("tests", "test_core.py"),
+	}
+
+	for _, file := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(cfg.ProjectPath, file)); os.IsNotExist(err) {
+			t.Errorf("Expected library file %s does not exist", file)
+		}
+	}
+
+	// The package must only exist under src/
+	if _, err := os.Stat(filepath.Join(cfg.ProjectPath, cfg.MainDirName)); !os.IsNotExist(err) {
+		t.Errorf("Library package should not be generated outside src/")
+	}
+
+	pyproject, err := os.ReadFile(filepath.Join(cfg.ProjectPath, "pyproject.toml"))
+	if err != nil {
+		t.Fatalf("Failed to read pyproject.toml: %v", err)
+	}
+	for _, want := range []string{
+		`build-backend = "setuptools.build_meta"`,
+		`package-dir = {"" = "src"}`,
+		`packages = ["test_library"]`,
+		`[project.optional-dependencies]`,
+		`"test_library" = ["py.typed"]`,
+	} {
+		if !strings.Contains(string(pyproject), want) {
+			t.Errorf("pyproject.toml is missing %q", want)
+		}
+	}
+}
+
+func TestDataScienceProjectGeneration(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := &config.ProjectConfig{
+		UserName:           "Data Test",
+		Email:              "data@test.com",
+		ProjectName:        "test-analysis",
+		ProjectDescription: "Data-science integration test project",
+		ProjectType:        "data-science",
+		ProjectPath:        filepath.Join(tempDir, "test-analysis"),
+		MainDirName:        "test_analysis",
+		PythonVersion:      "3.12",
+	}
+
+	gen := generator.New()
+	if err := gen.GenerateProject(cfg); err != nil {
+		t.Fatalf("Data-science project generation failed: %v", err)
+	}
+
+	expectedFiles := []string{
+		"README.md",
+		"pyproject.toml",
+		".pre-commit-config.yaml",
+		filepath.Join("data", "raw", ".gitkeep"),
+		filepath.Join("data", "processed", ".gitkeep"),
+		filepath.Join("models", ".gitkeep"),
+		filepath.Join("reports", "figures", ".gitkeep"),
+		filepath.Join("notebooks", "01-exploration.ipynb"),
+		filepath.Join(cfg.MainDirName, "__init__.py"),
+		filepath.Join(cfg.MainDirName, "features", "__init__.py"),
+		filepath.Join(cfg.MainDirName, "features", "build_features.py"),
+		filepath.Join("tests", "test_features.py"),
+	}
+
+	for _, file := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(cfg.ProjectPath, file)); os.IsNotExist(err) {
+			t.Errorf("Expected data-science file %s does not exist", file)
+		}
+	}
+
+	gitignore, err := os.ReadFile(filepath.Join(cfg.ProjectPath, ".gitignore"))
+	if err != nil {
+		t.Fatalf("Failed to read .gitignore: %v", err)
+	}
+	for _, rule := range []string{"data/raw/*", "!data/raw/.gitkeep", "models/*"} {
+		if !strings.Contains(string(gitignore), rule) {
+			t.Errorf(".gitignore is missing the data rule %q", rule)
+		}
+	}
+
+	preCommit, err := os.ReadFile(filepath.Join(cfg.ProjectPath, ".pre-commit-config.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read .pre-commit-config.yaml: %v", err)
+	}
+	if !strings.Contains(string(preCommit), "id: nbstripout") {
+		t.Error(".pre-commit-config.yaml does not configure nbstripout")
+	}
+}
+
 // Helper function to check if error is related to directory confirmation
 func isDirectoryConfirmationError(err error) bool {
 	return err != nil && (